@@ -0,0 +1,136 @@
+// Package bisect narrows a regression between a last-known-good and a
+// current-broken build to the adjacent pair of releases most likely to
+// contain it, by binary-searching an ordered list of release payloads (e.g.
+// a prow/OpenShift release-controller stream) for the point where a
+// component's version first changes.
+package bisect
+
+import (
+	"context"
+	"fmt"
+)
+
+// Version identifies a single build, e.g. "4.12.7".
+type Version string
+
+// Range is the narrowest pair of adjacent builds bisection found: Good is
+// the last build known not to contain the regression, Bad is the first one
+// that does.
+type Range struct {
+	Good Version
+	Bad  Version
+}
+
+// Commit is a single component-version change observed between Range.Good
+// and Range.Bad.
+type Commit struct {
+	Component string
+	From      string
+	To        string
+}
+
+// Lister provides the release payload data a Bisector searches over: the
+// ordered build history for a component, and the component-version manifest
+// of a given build (analogous to `oc adm release info --commits`).
+type Lister interface {
+	// ListPayloads returns the builds available for component, ordered
+	// oldest to newest.
+	ListPayloads(ctx context.Context, component string) ([]Version, error)
+	// ComponentVersions returns the component-to-version manifest of build,
+	// fetched from the stream configured for component.
+	ComponentVersions(ctx context.Context, component string, build Version) (map[string]string, error)
+}
+
+// Bisector narrows [good, bad] ranges to the adjacent pair of builds where a
+// component's version changed, caching each component's payload listing so
+// a sync across many new bugs only fetches it once.
+type Bisector struct {
+	lister Lister
+	cache  map[string][]Version
+}
+
+// NewBisector constructs a Bisector backed by lister.
+func NewBisector(lister Lister) *Bisector {
+	return &Bisector{lister: lister, cache: map[string][]Version{}}
+}
+
+func (b *Bisector) payloads(ctx context.Context, component string) ([]Version, error) {
+	if cached, ok := b.cache[component]; ok {
+		return cached, nil
+	}
+	payloads, err := b.lister.ListPayloads(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	b.cache[component] = payloads
+	return payloads, nil
+}
+
+// Bisect binary-searches component's release payloads between good and bad
+// for the narrowest adjacent range in which component's own version changed,
+// and returns that range together with the full component-version diff
+// between its endpoints. The invariant good < candidate < bad is maintained
+// by indexing into the ordered payload list; the search stops once the
+// range collapses to adjacent releases.
+func (b *Bisector) Bisect(ctx context.Context, component string, good, bad Version) (Range, []Commit, error) {
+	payloads, err := b.payloads(ctx, component)
+	if err != nil {
+		return Range{}, nil, err
+	}
+
+	goodIdx, badIdx := indexOf(payloads, good), indexOf(payloads, bad)
+	if goodIdx < 0 || badIdx < 0 {
+		return Range{}, nil, fmt.Errorf("bisect: build %q or %q not found among %s payloads", good, bad, component)
+	}
+	if goodIdx >= badIdx {
+		return Range{}, nil, fmt.Errorf("bisect: good build %q is not before bad build %q for %s", good, bad, component)
+	}
+
+	goodVersions, err := b.lister.ComponentVersions(ctx, component, good)
+	if err != nil {
+		return Range{}, nil, err
+	}
+	baseline := goodVersions[component]
+
+	lo, hi := goodIdx, badIdx
+	loVersions := goodVersions
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		midVersions, err := b.lister.ComponentVersions(ctx, component, payloads[mid])
+		if err != nil {
+			return Range{}, nil, err
+		}
+		if midVersions[component] == baseline {
+			lo, loVersions = mid, midVersions
+		} else {
+			hi = mid
+		}
+	}
+
+	badVersions, err := b.lister.ComponentVersions(ctx, component, payloads[hi])
+	if err != nil {
+		return Range{}, nil, err
+	}
+
+	result := Range{Good: payloads[lo], Bad: payloads[hi]}
+	return result, diffVersions(loVersions, badVersions), nil
+}
+
+func diffVersions(good, bad map[string]string) []Commit {
+	var commits []Commit
+	for comp, badVersion := range bad {
+		if goodVersion, ok := good[comp]; !ok || goodVersion != badVersion {
+			commits = append(commits, Commit{Component: comp, From: goodVersion, To: badVersion})
+		}
+	}
+	return commits
+}
+
+func indexOf(versions []Version, v Version) int {
+	for i, candidate := range versions {
+		if candidate == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,129 @@
+package bisect
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fakeLister is an in-memory Lister backed by a fixed payload order and a
+// per-component-per-build version table, so Bisect can be tested without a
+// real release-controller.
+type fakeLister struct {
+	payloads []Version
+	versions map[Version]map[string]string
+}
+
+func (f *fakeLister) ListPayloads(ctx context.Context, component string) ([]Version, error) {
+	return f.payloads, nil
+}
+
+func (f *fakeLister) ComponentVersions(ctx context.Context, component string, build Version) (map[string]string, error) {
+	return f.versions[build], nil
+}
+
+func TestBisect(t *testing.T) {
+	lister := &fakeLister{
+		payloads: []Version{"4.12.1", "4.12.2", "4.12.3", "4.12.4", "4.12.5"},
+		versions: map[Version]map[string]string{
+			"4.12.1": {"etcd": "v1", "kube-apiserver": "v1"},
+			"4.12.2": {"etcd": "v1", "kube-apiserver": "v1"},
+			"4.12.3": {"etcd": "v2", "kube-apiserver": "v1"},
+			"4.12.4": {"etcd": "v2", "kube-apiserver": "v1"},
+			"4.12.5": {"etcd": "v2", "kube-apiserver": "v2"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		component     string
+		good, bad     Version
+		wantRange     Range
+		wantComponent string
+		wantFrom      string
+		wantTo        string
+	}{
+		{
+			name:          "regression in the middle of the range",
+			component:     "etcd",
+			good:          "4.12.1",
+			bad:           "4.12.5",
+			wantRange:     Range{Good: "4.12.2", Bad: "4.12.3"},
+			wantComponent: "etcd",
+			wantFrom:      "v1",
+			wantTo:        "v2",
+		},
+		{
+			name:          "already adjacent",
+			component:     "kube-apiserver",
+			good:          "4.12.4",
+			bad:           "4.12.5",
+			wantRange:     Range{Good: "4.12.4", Bad: "4.12.5"},
+			wantComponent: "kube-apiserver",
+			wantFrom:      "v1",
+			wantTo:        "v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBisector(lister)
+			gotRange, commits, err := b.Bisect(context.Background(), tt.component, tt.good, tt.bad)
+			if err != nil {
+				t.Fatalf("Bisect returned error: %v", err)
+			}
+			if gotRange != tt.wantRange {
+				t.Errorf("range = %+v, want %+v", gotRange, tt.wantRange)
+			}
+
+			found := false
+			for _, c := range commits {
+				if c.Component == tt.wantComponent {
+					found = true
+					if c.From != tt.wantFrom || c.To != tt.wantTo {
+						t.Errorf("commit for %s = %+v, want From=%s To=%s", tt.wantComponent, c, tt.wantFrom, tt.wantTo)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("commits %+v missing entry for component %q", commits, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestBisectErrors(t *testing.T) {
+	lister := &fakeLister{
+		payloads: []Version{"4.12.1", "4.12.2", "4.12.3"},
+		versions: map[Version]map[string]string{
+			"4.12.1": {"etcd": "v1"},
+			"4.12.2": {"etcd": "v1"},
+			"4.12.3": {"etcd": "v2"},
+		},
+	}
+	b := NewBisector(lister)
+
+	if _, _, err := b.Bisect(context.Background(), "etcd", "4.12.1", "9.9.9"); err == nil {
+		t.Error("expected error for unknown bad build, got nil")
+	}
+	if _, _, err := b.Bisect(context.Background(), "etcd", "4.12.3", "4.12.1"); err == nil {
+		t.Error("expected error for good build not before bad build, got nil")
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	good := map[string]string{"etcd": "v1", "kube-apiserver": "v1"}
+	bad := map[string]string{"etcd": "v2", "kube-apiserver": "v1", "new-component": "v1"}
+
+	commits := diffVersions(good, bad)
+	sort.Slice(commits, func(i, j int) bool { return commits[i].Component < commits[j].Component })
+
+	want := []Commit{
+		{Component: "etcd", From: "v1", To: "v2"},
+		{Component: "new-component", From: "", To: "v1"},
+	}
+	if !reflect.DeepEqual(commits, want) {
+		t.Errorf("diffVersions = %+v, want %+v", commits, want)
+	}
+}
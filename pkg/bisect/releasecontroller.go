@@ -0,0 +1,116 @@
+package bisect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ReleaseControllerLister implements Lister against OpenShift's
+// release-controller API (e.g. https://amd64.ocp.releases.ci.openshift.org),
+// one endpoint per release stream. endpoints is keyed by component the same
+// way config.OperatorConfig.Components is, so each component can point at
+// the stream that builds it.
+type ReleaseControllerLister struct {
+	endpoints     map[string]string
+	componentTags map[string]string
+	client        *http.Client
+}
+
+// NewReleaseControllerLister constructs a ReleaseControllerLister. endpoints
+// maps a component name to the base URL of the release-controller stream
+// that builds it, as configured on config.OperatorConfig. componentTags maps
+// a Bugzilla component name (e.g. "Machine Config Operator") to the release
+// payload tag name release-controller publishes its version under (e.g.
+// "machine-config-operator"); a component missing from componentTags is
+// assumed to share its Bugzilla name with its payload tag name.
+func NewReleaseControllerLister(endpoints, componentTags map[string]string) *ReleaseControllerLister {
+	return &ReleaseControllerLister{endpoints: endpoints, componentTags: componentTags, client: http.DefaultClient}
+}
+
+// ListPayloads fetches the "Accepted" tags of component's release-controller
+// stream, oldest first.
+func (l *ReleaseControllerLister) ListPayloads(ctx context.Context, component string) ([]Version, error) {
+	base, ok := l.endpoints[component]
+	if !ok {
+		return nil, fmt.Errorf("no release-controller endpoint configured for component %q", component)
+	}
+
+	var tags []struct {
+		Name  string `json:"name"`
+		Phase string `json:"phase"`
+	}
+	if err := l.get(ctx, strings.TrimSuffix(base, "/")+"/api/v1/releasestream/4-stable/tags", &tags); err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, 0, len(tags))
+	for i := len(tags) - 1; i >= 0; i-- {
+		if tags[i].Phase != "Accepted" {
+			continue
+		}
+		versions = append(versions, Version(tags[i].Name))
+	}
+	return versions, nil
+}
+
+// ComponentVersions fetches the component-version manifest release-controller
+// publishes per build, keyed by release-payload tag name, plus an alias
+// entry under component itself (resolved through componentTags) so callers
+// that key into the result by the Bugzilla component name they passed in
+// get the right version even when it differs from the payload tag name.
+func (l *ReleaseControllerLister) ComponentVersions(ctx context.Context, component string, build Version) (map[string]string, error) {
+	base, ok := l.endpoints[component]
+	if !ok {
+		return nil, fmt.Errorf("no release-controller endpoint configured for component %q", component)
+	}
+
+	var pullSpec struct {
+		References struct {
+			Spec struct {
+				Tags []struct {
+					Name        string `json:"name"`
+					Annotations map[string]string
+				} `json:"tags"`
+			} `json:"spec"`
+		} `json:"references"`
+	}
+	if err := l.get(ctx, fmt.Sprintf("%s/releasestream/4-stable/release/%s", strings.TrimSuffix(base, "/"), build), &pullSpec); err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+	for _, tag := range pullSpec.References.Spec.Tags {
+		if v, ok := tag.Annotations["io.openshift.build.versions"]; ok {
+			versions[tag.Name] = v
+		}
+	}
+
+	tagName := component
+	if mapped, ok := l.componentTags[component]; ok {
+		tagName = mapped
+	}
+	if v, ok := versions[tagName]; ok {
+		versions[component] = v
+	}
+
+	return versions, nil
+}
+
+func (l *ReleaseControllerLister) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
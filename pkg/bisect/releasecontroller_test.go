@@ -0,0 +1,66 @@
+package bisect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestComponentVersionsTagNameMismatch covers the case where a Bugzilla
+// component name (e.g. "Machine Config Operator") differs from the release
+// payload's tag name (e.g. "machine-config-operator"): without componentTags,
+// versions[component] would never be set and Bisect would always see an
+// empty baseline.
+func TestComponentVersionsTagNameMismatch(t *testing.T) {
+	const bugzillaComponent = "Machine Config Operator"
+	const tagName = "machine-config-operator"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"references":{"spec":{"tags":[
+			{"name":"` + tagName + `","annotations":{"io.openshift.build.versions":"v1.2.3"}},
+			{"name":"etcd","annotations":{"io.openshift.build.versions":"v4.5.6"}}
+		]}}}`))
+	}))
+	defer server.Close()
+
+	lister := NewReleaseControllerLister(
+		map[string]string{bugzillaComponent: server.URL},
+		map[string]string{bugzillaComponent: tagName},
+	)
+
+	versions, err := lister.ComponentVersions(context.Background(), bugzillaComponent, "4.12.1")
+	if err != nil {
+		t.Fatalf("ComponentVersions returned error: %v", err)
+	}
+	if versions[bugzillaComponent] != "v1.2.3" {
+		t.Errorf("versions[%q] = %q, want v1.2.3", bugzillaComponent, versions[bugzillaComponent])
+	}
+	if versions[tagName] != "v1.2.3" {
+		t.Errorf("versions[%q] = %q, want v1.2.3 (diffVersions still needs the raw tag-keyed entry)", tagName, versions[tagName])
+	}
+}
+
+// TestComponentVersionsNoTagMapping covers the common case where a
+// component's Bugzilla name already matches its payload tag name, so no
+// componentTags entry is needed.
+func TestComponentVersionsNoTagMapping(t *testing.T) {
+	const component = "etcd"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"references":{"spec":{"tags":[
+			{"name":"etcd","annotations":{"io.openshift.build.versions":"v1"}}
+		]}}}`))
+	}))
+	defer server.Close()
+
+	lister := NewReleaseControllerLister(map[string]string{component: server.URL}, nil)
+
+	versions, err := lister.ComponentVersions(context.Background(), component, "4.12.1")
+	if err != nil {
+		t.Fatalf("ComponentVersions returned error: %v", err)
+	}
+	if versions[component] != "v1" {
+		t.Errorf("versions[%q] = %q, want v1", component, versions[component])
+	}
+}
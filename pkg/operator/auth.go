@@ -10,26 +10,46 @@ import (
 	"github.com/openshift-eng/shodan/pkg/slacker"
 )
 
-func auth(cfg config.OperatorConfig, handler func(req slacker.Request, w slacker.ResponseWriter), restrictedTo ...string) func(req slacker.Request, w slacker.ResponseWriter) {
+// ResolveUser maps the Slack user who issued req to their Bugzilla identity,
+// preferring the event's already-resolved Username and falling back to
+// looking up the Slack profile email via w.Client() and mapping it through
+// slack.SlackEmailToBugzilla. Both auth and command handlers that need to act
+// as a specific user (e.g. the new-bug reporter's uncc opt-out) share this.
+func ResolveUser(cfg config.OperatorConfig, req slacker.Request, w slacker.ResponseWriter) (string, error) {
+	if len(req.Event().Username) > 0 {
+		return req.Event().Username, nil
+	}
+
+	u, err := w.Client().GetUserInfo(req.Event().User)
+	if err != nil {
+		return "", err
+	}
+	if len(u.Profile.Email) == 0 {
+		return "", fmt.Errorf("user %q has no profile email set", req.Event().User)
+	}
+	return slack.SlackEmailToBugzilla(&cfg, u.Profile.Email), nil
+}
+
+// Auth wraps handler so it only runs for users config.ExpandGroups(cfg.Groups,
+// restrictedTo...) recognizes, replying with a permission-denied message
+// otherwise. Slash-command handlers elsewhere in pkg/operator/reporters (e.g.
+// the new-bug reporter's uncc command) route through this rather than
+// re-implementing identity resolution and the allow-list check themselves;
+// the new-bug reporter's interactive-callback handlers are registered
+// without it.
+func Auth(cfg config.OperatorConfig, handler func(req slacker.Request, w slacker.ResponseWriter), restrictedTo ...string) func(req slacker.Request, w slacker.ResponseWriter) {
 	users := config.ExpandGroups(cfg.Groups, restrictedTo...)
 
 	return func(req slacker.Request, w slacker.ResponseWriter) {
-		denied := func() {
+		user, err := ResolveUser(cfg, req, w)
+		if err != nil || !users.Has(user) {
 			w.Reply(fmt.Sprintf("Permission denied: User %q (%q) does not have permission to run this command", req.Event().Username, req.Event().User))
-		}
-		if len(req.Event().Username) == 0 || !users.Has(req.Event().Username) {
-			u, err := w.Client().GetUserInfo(req.Event().User)
 			if err != nil {
-				denied()
 				klog.Error(err)
-				return
-			}
-
-			if len(u.Profile.Email) == 0 || !users.Has(slack.SlackEmailToBugzilla(&cfg, u.Profile.Email)) {
-				denied()
-				klog.Errorf("Denied to: %#v", u)
-				return
+			} else {
+				klog.Errorf("Denied to: %q", user)
 			}
+			return
 		}
 
 		handler(req, w)
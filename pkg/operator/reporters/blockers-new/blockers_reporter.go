@@ -3,21 +3,35 @@ package blockers
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
+	"time"
 
-	"github.com/eparis/bugzilla"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+
+	bugzillatracker "github.com/mfojtik/bugzilla-operator/pkg/tracker/bugzilla"
 
-	"github.com/mfojtik/bugzilla-operator/pkg/cache"
-	"github.com/mfojtik/bugzilla-operator/pkg/operator/bugutil"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporting"
 	"github.com/mfojtik/bugzilla-operator/pkg/slack"
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
 )
 
+// reporterName identifies this reporter's bugs in the shared reporting.Store.
+const reporterName = "blockers"
+
+// weeklyReminderCadence is how often an unacknowledged, still-open blocker or
+// urgent bug gets re-pinged, keyed by how far it has progressed through the
+// reporting.Stage state machine: StageFirstPing covers the interval until
+// the second notification, StageWeeklyReminder every one after that.
+var weeklyReminderCadence = reporting.Cadence{
+	reporting.StageFirstPing:      7 * 24 * time.Hour,
+	reporting.StageWeeklyReminder: 7 * 24 * time.Hour,
+}
+
 type BlockersReporter struct {
 	controller.ControllerContext
 	config     config.OperatorConfig
@@ -43,13 +57,16 @@ var (
 	}
 )
 
-func NewBlockersReporter(ctx controller.ControllerContext, components []string, schedule []string, operatorConfig config.OperatorConfig,
+func NewBlockersReporter(ctx controller.ControllerContext, router reporting.ReplyRouter, components []string, schedule []string, operatorConfig config.OperatorConfig,
 	recorder events.Recorder) factory.Controller {
 	c := &BlockersReporter{
 		ControllerContext: ctx,
 		config:            operatorConfig,
 		components:        components,
 	}
+	if router != nil {
+		router.ThreadReply(reporterName, c.HandleReply)
+	}
 	return factory.New().WithSync(c.sync).ResyncSchedule(schedule...).ToController("BlockersReporter", recorder)
 }
 
@@ -65,116 +82,171 @@ type bugSummary struct {
 	needUpcomingSprintIDs  []int
 	urgentIDs              []int
 	urgent                 []string
-	staleCount             int
+	staleIDs               []int
 	priorityCount          map[string]int
 	severityCount          map[string]int
-	currentReleaseCount    int
+	currentReleaseIDs      []int
+}
+
+// ToTriageIDs, BlockerPlusIDs and UrgentIDs expose the bug IDs behind the
+// corresponding Slack message buckets to callers outside this package, such
+// as pkg/operator/web's per-component and per-assignee dashboards.
+func (s *bugSummary) ToTriageIDs() []int    { return s.toTriageIDs }
+func (s *bugSummary) BlockerPlusIDs() []int { return s.blockerPlusIDs }
+func (s *bugSummary) UrgentIDs() []int      { return s.urgentIDs }
+
+// formatIssueLine renders a single tracker.Issue as a Slack-linked one-liner,
+// backend-independent since it only relies on fields every adapter fills in.
+func formatIssueLine(issue tracker.Issue) string {
+	return fmt.Sprintf("<%s|#%d> %s", issue.URL, issue.ID, issue.Summary)
 }
 
-func summarizeBugs(currentTargetRelease string, bugs ...*bugzilla.Bug) bugSummary {
+// summarizeIssues buckets issues for the channel stats report and per-person
+// notifications. It is backend-independent: it only reads tracker.Issue
+// fields and flag semantics, which each adapter normalizes on the way in.
+func summarizeIssues(currentTargetRelease string, issues ...tracker.Issue) bugSummary {
 	r := bugSummary{
 		priorityCount: map[string]int{},
 		severityCount: map[string]int{},
 		seriousIDs:    map[string][]int{},
 	}
-	for _, bug := range bugs {
-		keywords := sets.NewString(bug.Keywords...)
+	for _, issue := range issues {
+		keywords := sets.NewString(issue.Keywords...)
 		for _, keyword := range seriousKeywords {
 			if keywords.Has(keyword) {
-				r.seriousIDs[keyword] = append(r.seriousIDs[keyword], bug.ID)
+				r.seriousIDs[keyword] = append(r.seriousIDs[keyword], issue.ID)
 			}
 		}
 
-		if strings.Contains(bug.Whiteboard, "LifecycleStale") {
-			r.staleCount++
+		if strings.Contains(issue.Whiteboard, "LifecycleStale") {
+			r.staleIDs = append(r.staleIDs, issue.ID)
 		}
 
-		r.severityCount[bug.Severity]++
-		r.priorityCount[bug.Priority]++
+		r.severityCount[issue.Severity]++
+		r.priorityCount[issue.Priority]++
 
-		if bug.Priority == "urgent" || bug.Severity == "urgent" {
-			r.urgent = append(r.urgent, bugutil.FormatBugMessage(*bug))
-			r.urgentIDs = append(r.urgentIDs, bug.ID)
+		if issue.Priority == "urgent" || issue.Severity == "urgent" {
+			r.urgent = append(r.urgent, formatIssueLine(issue))
+			r.urgentIDs = append(r.urgentIDs, issue.ID)
 		}
 
 		if !keywords.Has("UpcomingSprint") {
-			r.needUpcomingSprint = append(r.needUpcomingSprint, bugutil.FormatBugMessage(*bug))
-			r.needUpcomingSprintIDs = append(r.needUpcomingSprintIDs, bug.ID)
+			r.needUpcomingSprint = append(r.needUpcomingSprint, formatIssueLine(issue))
+			r.needUpcomingSprintIDs = append(r.needUpcomingSprintIDs, issue.ID)
 		}
 
 		targetRelease := "---"
-		if len(bug.TargetRelease) > 0 {
-			targetRelease = bug.TargetRelease[0]
+		if len(issue.TargetRelease) > 0 {
+			targetRelease = issue.TargetRelease[0]
 		}
 
-		if hasFlag(bug, "blocker", "+") && (targetRelease == currentTargetRelease || targetRelease == "---") {
-			r.blockerPlus = append(r.blockerPlus, bugutil.FormatBugMessage(*bug))
-			r.blockerPlusIDs = append(r.blockerPlusIDs, bug.ID)
-			r.seriousIDs["blocker+"] = append(r.seriousIDs["blocker+"], bug.ID)
+		if issue.HasFlag("blocker", "+") && (targetRelease == currentTargetRelease || targetRelease == "---") {
+			r.blockerPlus = append(r.blockerPlus, formatIssueLine(issue))
+			r.blockerPlusIDs = append(r.blockerPlusIDs, issue.ID)
+			r.seriousIDs["blocker+"] = append(r.seriousIDs["blocker+"], issue.ID)
 		}
 
-		if hasFlag(bug, "blocker", "?") && (targetRelease == currentTargetRelease || targetRelease == "---") {
-			r.blockerQuestionmark = append(r.blockerQuestionmark, bugutil.FormatBugMessage(*bug))
-			r.blockerQuestionmarkIDs = append(r.blockerQuestionmarkIDs, bug.ID)
-			r.seriousIDs["blocker?"] = append(r.seriousIDs["blocker?"], bug.ID)
+		if issue.HasFlag("blocker", "?") && (targetRelease == currentTargetRelease || targetRelease == "---") {
+			r.blockerQuestionmark = append(r.blockerQuestionmark, formatIssueLine(issue))
+			r.blockerQuestionmarkIDs = append(r.blockerQuestionmarkIDs, issue.ID)
+			r.seriousIDs["blocker?"] = append(r.seriousIDs["blocker?"], issue.ID)
 		}
 
 		triageState := sets.NewString("NEW", "")
-		if (targetRelease == currentTargetRelease && triageState.Has(bug.Status)) || targetRelease == "---" || bug.Priority == "unspecified" || bug.Priority == "" || bug.Severity == "unspecified" || bug.Severity == "" {
-			r.toTriage = append(r.toTriage, bugutil.FormatBugMessage(*bug))
-			r.toTriageIDs = append(r.toTriageIDs, bug.ID)
+		if (targetRelease == currentTargetRelease && triageState.Has(issue.Status)) || targetRelease == "---" || issue.Priority == "unspecified" || issue.Priority == "" || issue.Severity == "unspecified" || issue.Severity == "" {
+			r.toTriage = append(r.toTriage, formatIssueLine(issue))
+			r.toTriageIDs = append(r.toTriageIDs, issue.ID)
 		}
 
 		if targetRelease == currentTargetRelease || targetRelease == "---" {
-			r.currentReleaseCount++
+			r.currentReleaseIDs = append(r.currentReleaseIDs, issue.ID)
 		}
 	}
 
 	return r
 }
 
-func hasFlag(bug *bugzilla.Bug, name, value string) bool {
-	for _, f := range bug.Flags {
-		if f.Name == name && f.Status == value {
-			return true
-		}
+// notificationBucket pairs one severity bucket's bug IDs with their
+// pre-formatted Slack lines and the message template around them. Declaring
+// a bucket's ids and lines together, instead of threading them through
+// separate same-shaped variables per bucket, means a bucket can't
+// accidentally be wired up with another bucket's data.
+type notificationBucket struct {
+	name                 string
+	intro, suffix, outro string
+	ids                  []int
+	lines                []string
+}
+
+// notificationBuckets is the per-sync set of severity buckets sync notifies
+// assignees about, factored out so the wiring from summary to buckets is
+// unit testable without constructing a real sync.
+func notificationBuckets(summary bugSummary, currentTargetRelease string) []notificationBucket {
+	return []notificationBucket{
+		{name: "triage", intro: triageIntro, outro: triageOutro, ids: summary.toTriageIDs, lines: summary.toTriage},
+		{name: "blockerPlus", intro: blockerIntro, suffix: fmt.Sprintf("for the %s release", currentTargetRelease), outro: blockerOutro, ids: summary.blockerPlusIDs, lines: summary.blockerPlus},
+		{name: "urgent", intro: urgentIntro, outro: urgentOutro, ids: summary.urgentIDs, lines: summary.urgent},
 	}
-	return false
 }
 
 func (c *BlockersReporter) sync(ctx context.Context, syncCtx factory.SyncContext) error {
-	client := c.NewBugzillaClient(ctx)
+	trackerClient := bugzillatracker.New(c.NewBugzillaClient(ctx))
 	slackClient := c.SlackClient(ctx)
 
-	channelReport, summary, bugs, err := Report(ctx, client, syncCtx.Recorder(), &c.config, c.components)
+	channelReport, summary, issues, err := Report(ctx, trackerClient, syncCtx.Recorder(), &c.config, c.components)
 	if err != nil {
 		return err
 	}
 
-	byID := map[int]*bugzilla.Bug{}
-	for _, b := range bugs {
-		byID[b.ID] = b
+	byID := map[int]tracker.Issue{}
+	for _, issue := range issues {
+		byID[issue.ID] = issue
 	}
 
 	perPerson := func(ids []int, lines []string) (map[string][]int, map[string][]string) {
 		perPersonLines := map[string][]string{}
 		perPersonIDs := map[string][]int{}
 		for i, id := range ids {
-			b, ok := byID[id]
+			issue, ok := byID[id]
 			if !ok {
 				continue
 			}
-			perPersonLines[b.AssignedTo] = append(perPersonLines[b.AssignedTo], lines[i])
-			perPersonIDs[b.AssignedTo] = append(perPersonIDs[b.AssignedTo], id)
+			perPersonLines[issue.AssignedTo] = append(perPersonLines[issue.AssignedTo], lines[i])
+			perPersonIDs[issue.AssignedTo] = append(perPersonIDs[issue.AssignedTo], id)
 		}
 		return perPersonIDs, perPersonLines
 	}
 
-	perPersonToTriageIDs, perPersonToTriage := perPerson(summary.toTriageIDs, summary.toTriage)
-	perPersonBlockerPlusIDs, perPersonBlockerPlus := perPerson(summary.toTriageIDs, summary.toTriage)
-	perPersonUrgentIDs, perPersonUrgent := perPerson(summary.urgentIDs, summary.urgent)
+	store := reporting.NewStore(c.ControllerContext)
+	now := time.Now()
+
+	// dueOnly drops bugs that were already notified within weeklyReminderCadence
+	// or are currently silenced, so a bug pinged yesterday isn't pinged again
+	// today, and records each due bug's current stage so notifyPersons can
+	// advance it correctly.
+	dueOnly := func(ids map[string][]int, lines map[string][]string) (map[string][]int, map[string][]string, map[int]reporting.Stage) {
+		dueIDs := map[string][]int{}
+		dueLines := map[string][]string{}
+		stages := map[int]reporting.Stage{}
+		for person, personIDs := range ids {
+			for i, id := range personIDs {
+				due, state, err := store.Due(ctx, reporterName, id, now, weeklyReminderCadence)
+				if err != nil {
+					klog.Warningf("Cannot read reporting state for bug #%d: %v", id, err)
+					continue
+				}
+				if !due {
+					continue
+				}
+				dueIDs[person] = append(dueIDs[person], id)
+				dueLines[person] = append(dueLines[person], lines[person][i])
+				stages[id] = state.Stage
+			}
+		}
+		return dueIDs, dueLines, stages
+	}
 
-	notifyPersons := func(intro, suffix string, perPersonBugs map[string][]string, outro string) {
+	notifyPersons := func(intro, suffix string, perPersonIDs map[string][]int, perPersonBugs map[string][]string, outro string, stages map[int]reporting.Stage) {
 		for person, lines := range perPersonBugs {
 			if len(lines) == 0 {
 				continue
@@ -182,115 +254,120 @@ func (c *BlockersReporter) sync(ctx context.Context, syncCtx factory.SyncContext
 			message := fmt.Sprintf("%s%s%s", fmt.Sprintf(intro, len(lines), suffix), strings.Join(lines, "\n"), outro)
 			if err := slackClient.MessageEmail(person, message); err != nil {
 				syncCtx.Recorder().Warningf("DeliveryFailed", "Failed to deliver:\n\n%s\n\n to %q: %v", message, person, err)
+				continue
+			}
+			for _, id := range perPersonIDs[person] {
+				if err := store.Notified(ctx, reporterName, id, now, reporting.NextStage(stages[id])); err != nil {
+					klog.Warningf("Cannot persist reporting state for bug #%d: %v", id, err)
+				}
 			}
 		}
 	}
 
-	notifyPersons(triageIntro, "", perPersonToTriage, triageOutro)
-	notifyPersons(blockerIntro, fmt.Sprintf("for the %s release", c.config.Release.CurrentTargetRelease), perPersonBlockerPlus, blockerOutro)
-	notifyPersons(urgentIntro, "", perPersonUrgent, urgentOutro)
+	perPersonIDsByBucket := map[string]map[string][]int{}
+	for _, bucket := range notificationBuckets(*summary, c.config.Release.CurrentTargetRelease) {
+		perPersonIDs, perPersonLines := perPerson(bucket.ids, bucket.lines)
+		perPersonIDsByBucket[bucket.name] = perPersonIDs
+		duePerPersonIDs, duePerPersonLines, stages := dueOnly(perPersonIDs, perPersonLines)
+		notifyPersons(bucket.intro, bucket.suffix, duePerPersonIDs, duePerPersonLines, bucket.outro, stages)
+	}
 
 	if err := slackClient.MessageChannel(channelReport); err != nil {
 		syncCtx.Recorder().Warningf("DeliveryFailed", "Failed to deliver stats to channel: %v", err)
 	}
 
 	// send debug stats
-	c.sendAdminDebugStats(slackClient, perPersonBlockerPlusIDs, perPersonToTriageIDs, perPersonUrgentIDs)
+	c.sendAdminDebugStats(trackerClient, slackClient, perPersonIDsByBucket["blockerPlus"], perPersonIDsByBucket["triage"], perPersonIDsByBucket["urgent"])
 	return nil
 }
 
-func getBugsQuery(config *config.OperatorConfig, components []string, targetRelease []string) bugzilla.Query {
-	return bugzilla.Query{
-		Classification: []string{"Red Hat"},
-		Product:        []string{"OpenShift Container Platform"},
-		Status:         []string{"NEW", "ASSIGNED", "POST", "ON_DEV"},
-		Component:      components,
-		TargetRelease:  targetRelease,
-		Advanced: []bugzilla.AdvancedQuery{
-			{
-				Field: "bug_severity",
-				Op:    "notequals",
-				Value: "low",
-			},
-			{
-				Field: "priority",
-				Op:    "notequals",
-				Value: "low",
-			},
-		},
-		IncludeFields: []string{
-			"id",
-			"assigned_to",
-			"keywords",
-			"status",
-			"resolution",
-			"summary",
-			"changeddate",
-			"severity",
-			"priority",
-			"target_release",
-			"whiteboard",
-			"flags",
-		},
+// HandleReply applies a free-text "ack [duration]" or "silence" reply to a
+// bug's reporting state, e.g. "ack 7d" silences reminders for bugID for a
+// week, and a bare "silence" silences it indefinitely.
+func (c *BlockersReporter) HandleReply(ctx context.Context, bugID int, reply string) error {
+	return reporting.NewStore(c.ControllerContext).HandleReply(ctx, reporterName, bugID, reply)
+}
+
+// buildQuery is the backend-independent shape of the "active, non-low
+// priority/severity bugs in these components" search every Report() release
+// query needs; each tracker.Client adapter translates it into its backend's
+// native query.
+func buildQuery(components []string, targetRelease []string) tracker.Query {
+	return tracker.Query{
+		Statuses:                   []string{"NEW", "ASSIGNED", "POST", "ON_DEV"},
+		Components:                 components,
+		TargetRelease:              targetRelease,
+		ExcludeLowPrioritySeverity: true,
 	}
 }
 
-func Report(ctx context.Context, client cache.BugzillaClient, recorder events.Recorder, config *config.OperatorConfig, components []string) (string, *bugSummary, []*bugzilla.Bug, error) {
-	allReleasesQuery := getBugsQuery(config, components, append([]string{"---"}, config.Release.TargetReleases...))
-	currentReleaseQeury := getBugsQuery(config, components, append([]string{"---"}, config.Release.CurrentTargetRelease))
+// AllData queries the active bugs in components and summarizes them, without
+// any Slack formatting or events.Recorder dependency. It is the entry point
+// pkg/operator/web uses for live per-component and per-assignee dashboards.
+func AllData(client tracker.Client, config *config.OperatorConfig, components []string) (*bugSummary, []tracker.Issue, error) {
+	allReleasesQuery := buildQuery(components, append([]string{"---"}, config.Release.TargetReleases...))
+
+	issues, err := client.Search(allReleasesQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := summarizeIssues(config.Release.CurrentTargetRelease, issues...)
+	return &summary, issues, nil
+}
 
-	bugs, err := client.Search(allReleasesQuery)
+func Report(ctx context.Context, client tracker.Client, recorder events.Recorder, config *config.OperatorConfig, components []string) (string, *bugSummary, []tracker.Issue, error) {
+	summary, issues, err := AllData(client, config, components)
 	if err != nil {
 		recorder.Warningf("BugSearchFailed", err.Error())
 		return "", nil, nil, err
 	}
 
-	summary := summarizeBugs(config.Release.CurrentTargetRelease, bugs...)
-	channelStats := getStatsForChannel(
-		config.Release.CurrentTargetRelease,
-		len(bugs),
-		summary,
-		allReleasesQuery,
-		currentReleaseQeury,
-	)
+	allIDs := make([]int, len(issues))
+	for i, issue := range issues {
+		allIDs[i] = issue.ID
+	}
+	channelStats := getStatsForChannel(client, config.Release.CurrentTargetRelease, allIDs, *summary)
 
 	report := fmt.Sprintf("\n:bug: *Today 4.x Bug Report:* :bug:\n%s\n", strings.Join(channelStats, "\n"))
-	return report, &summary, nil, nil
+	return report, summary, issues, nil
 }
 
-func makeBugzillaLink(hrefText string, ids ...int) string {
-	u, _ := url.Parse("https://bugzilla.redhat.com/buglist.cgi?f1=bug_id&list_id=11100046&o1=anyexact&query_format=advanced")
-	e := u.Query()
-	stringIds := make([]string, len(ids))
-	for i := range stringIds {
-		stringIds[i] = fmt.Sprintf("%d", ids[i])
+// joinIssueLinks is the backend-agnostic stand-in for a single combined
+// search-results link: every adapter can produce a per-issue Link(), but only
+// Bugzilla's buglist.cgi supports linking to an arbitrary ID set in one URL.
+func joinIssueLinks(client tracker.Client, ids []int) string {
+	links := make([]string, len(ids))
+	for i, id := range ids {
+		links[i] = fmt.Sprintf("<%s|#%d>", client.Link(id), id)
 	}
-	e.Add("v1", strings.Join(stringIds, ","))
-	u.RawQuery = e.Encode()
-	return fmt.Sprintf("<%s|%s>", u.String(), hrefText)
+	return strings.Join(links, " ")
 }
 
-func (c *BlockersReporter) sendAdminDebugStats(slackClient slack.ChannelClient, perPersonBlockersIDs, perPersonToTriageIDs, perPersonUrgentIDs map[string][]int) {
+func (c *BlockersReporter) sendAdminDebugStats(trackerClient tracker.Client, slackClient slack.ChannelClient, perPersonBlockersIDs, perPersonToTriageIDs, perPersonUrgentIDs map[string][]int) {
 	var messages []string
 	for person, b := range perPersonBlockersIDs {
 		if len(b) > 0 {
-			messages = append(messages, fmt.Sprintf("> %s: %d blocker+ bugs", makeBugzillaLink(person, perPersonBlockersIDs[person]...), len(b)))
+			messages = append(messages, fmt.Sprintf("> %s: %d blocker+ bugs: %s", person, len(b), joinIssueLinks(trackerClient, b)))
 		}
 	}
 	for person, b := range perPersonToTriageIDs {
 		if len(b) > 0 {
-			messages = append(messages, fmt.Sprintf("> %s: %d bugs that need triage", makeBugzillaLink(person, perPersonToTriageIDs[person]...), len(b)))
+			messages = append(messages, fmt.Sprintf("> %s: %d bugs that need triage: %s", person, len(b), joinIssueLinks(trackerClient, b)))
 		}
 	}
 	for person, b := range perPersonUrgentIDs {
 		if len(b) > 0 {
-			messages = append(messages, fmt.Sprintf("> %s: %d urgent bugs", makeBugzillaLink(person, perPersonUrgentIDs[person]...), len(b)))
+			messages = append(messages, fmt.Sprintf("> %s: %d urgent bugs: %s", person, len(b), joinIssueLinks(trackerClient, b)))
 		}
 	}
 	slackClient.MessageAdminChannel(strings.Join(messages, "\n"))
 }
 
-func getStatsForChannel(targetRelease string, activeBugsCount int, summary bugSummary, allReleasesQuery, currentReleaseQuery bugzilla.Query) []string {
+// getStatsForChannel renders the channel stats report, linking each bucket
+// through joinIssueLinks so the counts stay clickable regardless of backend
+// (the old Bugzilla-only buglist.cgi query links aren't backend-agnostic).
+func getStatsForChannel(client tracker.Client, targetRelease string, allIDs []int, summary bugSummary) []string {
 	sortedPrioNames := []string{
 		"urgent",
 		"high",
@@ -311,21 +388,17 @@ func getStatsForChannel(targetRelease string, activeBugsCount int, summary bugSu
 		}
 	}
 
-	allReleasesQueryURL, _ := url.Parse("https://bugzilla.redhat.com/buglist.cgi?" + allReleasesQuery.Values().Encode())
-	currentReleaseQueryURL, _ := url.Parse("https://bugzilla.redhat.com/buglist.cgi?" + currentReleaseQuery.Values().Encode())
-
 	lines := []string{
-		fmt.Sprintf("> All active 4.x and 3.11 Bugs: <%s|%d>", allReleasesQueryURL.String(), activeBugsCount),
-		fmt.Sprintf("> All active %s Bugs: <%s|%d>", targetRelease, currentReleaseQueryURL.String(), summary.currentReleaseCount),
+		fmt.Sprintf("> All active 4.x and 3.11 Bugs: %d %s", len(allIDs), joinIssueLinks(client, allIDs)),
+		fmt.Sprintf("> All active %s Bugs: %d %s", targetRelease, len(summary.currentReleaseIDs), joinIssueLinks(client, summary.currentReleaseIDs)),
 		fmt.Sprintf("> Bugs Severity Breakdown: %s", strings.Join(severityMessages, ", ")),
 		fmt.Sprintf("> Bugs Priority Breakdown: %s", strings.Join(priorityMessages, ", ")),
-		fmt.Sprintf("> Bugs Marked as _LifecycleStale_: <https://bugzilla.redhat.com/buglist.cgi?cmdtype=dorem&remaction=run&namedcmd=openshift-group-b-lifecycle-stale&sharer_id=290313|%d>", summary.staleCount),
+		fmt.Sprintf("> Bugs Marked as _LifecycleStale_: %d %s", len(summary.staleIDs), joinIssueLinks(client, summary.staleIDs)),
 	}
 
 	for keyword, ids := range summary.seriousIDs {
 		if len(ids) > 0 {
-			keywordURL := makeBugzillaLink(fmt.Sprintf("%d", len(ids)), ids...)
-			lines = append(lines, fmt.Sprintf("> Bugs with _%s_: %s", keyword, keywordURL))
+			lines = append(lines, fmt.Sprintf("> Bugs with _%s_: %d %s", keyword, len(ids), joinIssueLinks(client, ids)))
 		}
 	}
 
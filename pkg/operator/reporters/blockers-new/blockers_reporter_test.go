@@ -0,0 +1,36 @@
+package blockers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNotificationBuckets(t *testing.T) {
+	summary := bugSummary{
+		toTriage:    []string{"triage line"},
+		toTriageIDs: []int{1},
+
+		blockerPlus:    []string{"blocker line"},
+		blockerPlusIDs: []int{2},
+
+		urgent:    []string{"urgent line"},
+		urgentIDs: []int{3},
+	}
+
+	buckets := notificationBuckets(summary, "4.12")
+
+	byName := map[string]notificationBucket{}
+	for _, b := range buckets {
+		byName[b.name] = b
+	}
+
+	if !reflect.DeepEqual(byName["triage"].ids, summary.toTriageIDs) || !reflect.DeepEqual(byName["triage"].lines, summary.toTriage) {
+		t.Errorf("triage bucket = %+v, want ids/lines from toTriage*", byName["triage"])
+	}
+	if !reflect.DeepEqual(byName["blockerPlus"].ids, summary.blockerPlusIDs) || !reflect.DeepEqual(byName["blockerPlus"].lines, summary.blockerPlus) {
+		t.Errorf("blockerPlus bucket = %+v, want ids/lines from blockerPlus*, not toTriage*", byName["blockerPlus"])
+	}
+	if !reflect.DeepEqual(byName["urgent"].ids, summary.urgentIDs) || !reflect.DeepEqual(byName["urgent"].lines, summary.urgent) {
+		t.Errorf("urgent bucket = %+v, want ids/lines from urgent*", byName["urgent"])
+	}
+}
@@ -4,41 +4,57 @@ import (
 	"context"
 	"fmt"
 	"strings"
-
-	"github.com/mfojtik/bugzilla-operator/pkg/operator/bugutil"
+	"time"
 
 	"github.com/mfojtik/bugzilla-operator/pkg/slack"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
 
-	"github.com/eparis/bugzilla"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 
-	"github.com/mfojtik/bugzilla-operator/pkg/cache"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporting"
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+	bugzillatracker "github.com/mfojtik/bugzilla-operator/pkg/tracker/bugzilla"
 )
 
+// reporterName identifies this reporter's bugs in the shared reporting.Store.
+const reporterName = "escalation"
+
+// escalationReminderCadence is how often a still-escalated bug gets
+// re-reported after its first ping. Escalated bugs go straight to the
+// terminal StageLeadEscalation (see Report below), so only that stage needs
+// an entry.
+var escalationReminderCadence = reporting.Cadence{
+	reporting.StageLeadEscalation: 7 * 24 * time.Hour,
+}
+
 type EscalationReporter struct {
 	controller.ControllerContext
 	config     config.OperatorConfig
 	components []string
 }
 
-func NewEscalationReporter(ctx controller.ControllerContext, components []string, schedule []string, operatorConfig config.OperatorConfig, recorder events.Recorder) factory.Controller {
+func NewEscalationReporter(ctx controller.ControllerContext, router reporting.ReplyRouter, components []string, schedule []string, operatorConfig config.OperatorConfig, recorder events.Recorder) factory.Controller {
 	c := &EscalationReporter{
 		ControllerContext: ctx,
 		config:            operatorConfig,
 		components:        components,
 	}
+	if router != nil {
+		router.ThreadReply(reporterName, c.HandleReply)
+	}
 	return factory.New().WithSync(c.sync).ResyncSchedule(schedule...).ToController("UrgentStatsReporter", recorder)
 }
 
 func (c *EscalationReporter) sync(ctx context.Context, syncCtx factory.SyncContext) error {
-	client := c.NewBugzillaClient(ctx)
+	trackerClient := bugzillatracker.New(c.NewBugzillaClient(ctx))
 	slackClient := c.SlackClient(ctx)
 
-	report, err := Report(ctx, client, slackClient, syncCtx.Recorder(), &c.config, c.components)
+	store := reporting.NewStore(c.ControllerContext)
+	report, err := Report(ctx, trackerClient, slackClient, syncCtx.Recorder(), &c.config, c.components, store)
 	if err != nil {
 		return err
 	}
@@ -54,56 +70,77 @@ func (c *EscalationReporter) sync(ctx context.Context, syncCtx factory.SyncConte
 	return nil
 }
 
-func Report(ctx context.Context, client cache.BugzillaClient, slack slack.ChannelClient, recorder events.Recorder, cfg *config.OperatorConfig, components []string) (string, error) {
-	urgentSeverityBugs, err := getSeverityUrgentBugs(client, cfg, components)
-	if err != nil {
-		recorder.Warningf("BugSearchFailed", err.Error())
-		return "", err
+// Data is the structured result of grouping the urgent/escalated issues by
+// component lead and assignee, independent of how (or whether) it is
+// rendered as a Slack message. It is what pkg/operator/web's /escalations
+// and /lead/<email> pages are built from.
+type Data struct {
+	LeadsBugs         map[string][]tracker.Issue
+	Assigned          map[string][]tracker.Issue
+	Silenced          []tracker.Issue
+	MissingComponents []string
+}
+
+// hasCustomerCase reports whether issue carries an SFDC (Salesforce) external
+// link, the tracker-agnostic stand-in for Bugzilla's external_bugs.
+func hasCustomerCase(issue tracker.Issue) bool {
+	for _, l := range issue.ExternalLinks {
+		if l.System == "SFDC" {
+			return true
+		}
 	}
+	return false
+}
 
-	assigned := map[string][]*bugzilla.Bug{}
-	silenced := []*bugzilla.Bug{}
-	leadsBugs := map[string][]*bugzilla.Bug{}
+// ComputeData groups the urgent/escalated issues in components by component
+// lead and assignee. It performs no notification throttling: callers that
+// want the standard weekly-cadence dedupe (the Slack reporter) should filter
+// the input through a reporting.Store first; callers that want a live
+// snapshot (the web UI) can pass the issues straight through.
+func ComputeData(issues []tracker.Issue, cfg *config.OperatorConfig) Data {
+	assigned := map[string][]tracker.Issue{}
+	silenced := []tracker.Issue{}
+	leadsBugs := map[string][]tracker.Issue{}
 	missingComponents := sets.NewString()
-	for _, b := range urgentSeverityBugs {
-		escalationFlag := b.Escalation == "Yes"
-		customerCases := false
-		for _, eb := range b.ExternalBugs {
-			if eb.Type.Type == "SFDC" {
-				customerCases = true
-				break
-			}
-		}
+	for _, issue := range issues {
+		customerCases := hasCustomerCase(issue)
 
-		if escalationFlag || (customerCases && b.Priority == "urgent") || (customerCases && b.Severity == "urgent" && b.Priority == "unspecified") {
-			assigned[b.AssignedTo] = append(assigned[b.AssignedTo], b)
+		if issue.Escalated || (customerCases && issue.Priority == "urgent") || (customerCases && issue.Severity == "urgent" && issue.Priority == "unspecified") {
+			assigned[issue.AssignedTo] = append(assigned[issue.AssignedTo], issue)
 
-			if len(b.Component) > 0 {
-				comp, ok := cfg.Components[b.Component[0]]
+			if len(issue.Component) > 0 {
+				comp, ok := cfg.Components[issue.Component[0]]
 				if !ok {
-					missingComponents.Insert(b.Component[0])
+					missingComponents.Insert(issue.Component[0])
 				}
 
 				if len(comp.Lead) > 0 {
-					leadsBugs[comp.Lead] = append(leadsBugs[comp.Lead], b)
+					leadsBugs[comp.Lead] = append(leadsBugs[comp.Lead], issue)
 				}
 			}
-		} else if b.Severity == "urgent" && b.Priority != "unspecified" {
-			silenced = append(silenced, b)
+		} else if issue.Severity == "urgent" && issue.Priority != "unspecified" {
+			silenced = append(silenced, issue)
 		}
 	}
 
-	if len(missingComponents) > 0 && slack != nil {
-		slack.MessageAdminChannel(fmt.Sprintf("Missing components in config: %s", strings.Join(missingComponents.List(), ", ")))
+	return Data{
+		LeadsBugs:         leadsBugs,
+		Assigned:          assigned,
+		Silenced:          silenced,
+		MissingComponents: missingComponents.List(),
 	}
+}
 
-	if len(leadsBugs) == 0 && len(silenced) == 0 {
-		return "", nil
+// formatReport renders data as the Slack escalation report message. It
+// returns "" if there is nothing to report.
+func formatReport(data Data, cfg *config.OperatorConfig) string {
+	if len(data.LeadsBugs) == 0 && len(data.Silenced) == 0 {
+		return ""
 	}
 
 	lines := []string{"Escalation report:", ""}
 
-	for lead, bugs := range leadsBugs {
+	for lead, issues := range data.LeadsBugs {
 		roots := sets.NewString()
 		for _, comp := range cfg.Components {
 			if comp.Lead == lead {
@@ -113,20 +150,20 @@ func Report(ctx context.Context, client cache.BugzillaClient, slack slack.Channe
 		team := config.ExpandGroups(cfg.Groups, roots.List()...)
 		maxEscalations := max(1, int(float64(len(team))*0.2))
 
-		if len(bugs) > maxEscalations {
-			lines = append(lines, fmt.Sprintf(":red-siren: %s's team with %d bugs, above the quota of %d", lead, len(bugs), maxEscalations))
+		if len(issues) > maxEscalations {
+			lines = append(lines, fmt.Sprintf(":red-siren: %s's team with %d bugs, above the quota of %d", lead, len(issues), maxEscalations))
 		} else {
-			lines = append(lines, fmt.Sprintf("%s's team with %d bug", lead, len(bugs)))
+			lines = append(lines, fmt.Sprintf("%s's team with %d bug", lead, len(issues)))
 		}
 
-		for _, b := range bugs {
-			lines = append(lines, fmt.Sprintf("> %s %s @ %s: %s", bugutil.GetBugURL(*b), b.Status, b.AssignedTo, b.Summary))
+		for _, issue := range issues {
+			lines = append(lines, fmt.Sprintf("> <%s|#%d> %s @ %s: %s", issue.URL, issue.ID, issue.Status, issue.AssignedTo, issue.Summary))
 		}
 	}
 
 	first := true
-	for assignee, bugs := range assigned {
-		if len(bugs) == 1 {
+	for assignee, issues := range data.Assigned {
+		if len(issues) == 1 {
 			continue
 		}
 
@@ -137,22 +174,91 @@ func Report(ctx context.Context, client cache.BugzillaClient, slack slack.Channe
 		}
 
 		links := []string{}
-		for _, b := range bugs {
-			links = append(links, bugutil.GetBugURL(*b))
+		for _, issue := range issues {
+			links = append(links, fmt.Sprintf("<%s|#%d>", issue.URL, issue.ID))
 		}
 
 		lines = append(lines, fmt.Sprintf("> :red-siren: %s: %s", assignee, strings.Join(links, " ")))
 	}
 
-	if len(silenced) > 0 {
+	if len(data.Silenced) > 0 {
 		links := []string{}
-		for _, b := range silenced {
-			links = append(links, bugutil.GetBugURL(*b))
+		for _, issue := range data.Silenced {
+			links = append(links, fmt.Sprintf("<%s|#%d>", issue.URL, issue.ID))
 		}
 		lines = append(lines, "", fmt.Sprintf("%d silenced bugs :see_no_evil: : %s", len(links), strings.Join(links, " ")))
 	}
 
-	return strings.Join(lines, "\n"), nil
+	return strings.Join(lines, "\n")
+}
+
+// Report queries the urgent/escalated issues and renders the channel report.
+// If store is non-nil, issues that were already (re-)reported within
+// escalationReminderCadence or are currently silenced are skipped, and every
+// issue listed under a component lead is recorded as notified so it isn't
+// re-sent tomorrow. Issues that appear only in the silenced list or under an
+// assignee with no configured lead are not recorded, and appear again on the
+// next run.
+func Report(ctx context.Context, client tracker.Client, slack slack.ChannelClient, recorder events.Recorder, cfg *config.OperatorConfig, components []string, store *reporting.Store) (string, error) {
+	urgentSeverityIssues, err := getSeverityUrgentIssues(client, components)
+	if err != nil {
+		recorder.Warningf("BugSearchFailed", err.Error())
+		return "", err
+	}
+
+	if store != nil {
+		now := time.Now()
+		due := urgentSeverityIssues[:0]
+		for _, issue := range urgentSeverityIssues {
+			ok, _, err := store.Due(ctx, reporterName, issue.ID, now, escalationReminderCadence)
+			if err != nil {
+				klog.Warningf("Cannot read reporting state for bug #%d: %v", issue.ID, err)
+				continue
+			}
+			if ok {
+				due = append(due, issue)
+			}
+		}
+		urgentSeverityIssues = due
+	}
+
+	data := ComputeData(urgentSeverityIssues, cfg)
+
+	if len(data.MissingComponents) > 0 && slack != nil {
+		slack.MessageAdminChannel(fmt.Sprintf("Missing components in config: %s", strings.Join(data.MissingComponents, ", ")))
+	}
+
+	report := formatReport(data, cfg)
+
+	if store != nil {
+		now := time.Now()
+		for _, issues := range data.LeadsBugs {
+			for _, issue := range issues {
+				if err := store.Notified(ctx, reporterName, issue.ID, now, reporting.StageLeadEscalation); err != nil {
+					klog.Warningf("Cannot persist reporting state for bug #%d: %v", issue.ID, err)
+				}
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// AllData queries the urgent/escalated issues and groups them, without any
+// notification throttling. It is the entry point pkg/operator/web uses for
+// live dashboards.
+func AllData(client tracker.Client, cfg *config.OperatorConfig, components []string) (Data, error) {
+	issues, err := getSeverityUrgentIssues(client, components)
+	if err != nil {
+		return Data{}, err
+	}
+	return ComputeData(issues, cfg), nil
+}
+
+// HandleReply applies a free-text "ack [duration]" or "silence" reply to a
+// bug's escalation reporting state, e.g. "ack 7d" silences it for a week.
+func (c *EscalationReporter) HandleReply(ctx context.Context, bugID int, reply string) error {
+	return reporting.NewStore(c.ControllerContext).HandleReply(ctx, reporterName, bugID, reply)
 }
 
 func max(x, y int) int {
@@ -162,21 +268,9 @@ func max(x, y int) int {
 	return y
 }
 
-func getSeverityUrgentBugs(client cache.BugzillaClient, config *config.OperatorConfig, components []string) ([]*bugzilla.Bug, error) {
-	return client.Search(bugzilla.Query{
-		Classification: []string{"Red Hat"},
-		Product:        []string{"OpenShift Container Platform"},
-		Status:         []string{"NEW", "ASSIGNED", "POST", "ON_DEV"},
-		Component:      components,
-		IncludeFields: []string{
-			"id",
-			"assigned_to",
-			"status",
-			"severity",
-			"priority",
-			"external_bugs",
-			"component",
-			"summary",
-		},
+func getSeverityUrgentIssues(client tracker.Client, components []string) ([]tracker.Issue, error) {
+	return client.Search(tracker.Query{
+		Statuses:   []string{"NEW", "ASSIGNED", "POST", "ON_DEV"},
+		Components: components,
 	})
 }
@@ -6,35 +6,62 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/eparis/bugzilla"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 	errorutil "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 
-	"github.com/mfojtik/bugzilla-operator/pkg/cache"
-	"github.com/mfojtik/bugzilla-operator/pkg/operator/bugutil"
+	"github.com/mfojtik/bugzilla-operator/pkg/bisect"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
 	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+	"github.com/mfojtik/bugzilla-operator/pkg/slack"
+	"github.com/mfojtik/bugzilla-operator/pkg/slacker"
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+	bugzillatracker "github.com/mfojtik/bugzilla-operator/pkg/tracker/bugzilla"
 )
 
 type NewBugReporter struct {
 	controller.ControllerContext
 	config     config.OperatorConfig
 	components []string
+	bisector   *bisect.Bisector
 }
 
-func NewNewBugReporter(ctx controller.ControllerContext, components, schedule []string, operatorConfig config.OperatorConfig, recorder events.Recorder) factory.Controller {
+// CommandRouter is the subset of a Slack command/interactivity router (e.g.
+// pkg/slacker's bot) NewNewBugReporter needs in order to make UnccCommand and
+// Actions reachable, so registration happens once at construction instead of
+// being left for some caller to remember to wire up.
+type CommandRouter interface {
+	// Command registers handler for the slash command named usage (e.g.
+	// "bug uncc").
+	Command(usage string, handler func(req slacker.Request, w slacker.ResponseWriter))
+	// CallbackHandler registers handler as the interactive-message callback
+	// for action.
+	CallbackHandler(action string, handler ActionHandler)
+}
+
+// NewNewBugReporter constructs a NewBugReporter and, if router is non-nil,
+// registers its uncc slash command and button-callback actions with it.
+func NewNewBugReporter(ctx controller.ControllerContext, router CommandRouter, components, schedule []string, operatorConfig config.OperatorConfig, recorder events.Recorder) factory.Controller {
 	c := &NewBugReporter{
 		ctx,
 		operatorConfig,
 		components,
+		bisect.NewBisector(bisect.NewReleaseControllerLister(operatorConfig.CIReleaseEndpoints, operatorConfig.CIReleaseComponentTags)),
+	}
+	if router != nil {
+		router.Command("bug uncc", c.UnccCommand())
+		for action, handler := range c.Actions() {
+			router.CallbackHandler(action, handler)
+		}
 	}
 	return factory.New().WithSync(c.sync).ResyncSchedule(schedule...).ToController("NewBugReporter", recorder)
 }
 
 func (c *NewBugReporter) sync(ctx context.Context, syncCtx factory.SyncContext) (err error) {
-	client := c.NewBugzillaClient(ctx)
+	trackerClient := bugzillatracker.New(c.NewBugzillaClient(ctx))
 	slackClient := c.SlackClient(ctx)
 
 	stateKey := "new-bug-reporter.state-" + strings.Join(c.components, "-")
@@ -56,7 +83,7 @@ func (c *NewBugReporter) sync(ctx context.Context, syncCtx factory.SyncContext)
 		}
 	}()
 
-	newBugs, err := getNewBugs(client, c.components, lastID)
+	newIssues, err := getNewIssues(trackerClient, c.components, lastID)
 	if err != nil {
 		syncCtx.Recorder().Warningf("BuglistFailed", err.Error())
 		return err
@@ -64,34 +91,45 @@ func (c *NewBugReporter) sync(ctx context.Context, syncCtx factory.SyncContext)
 
 	var errs []error
 	ids := []string{}
-	for i, b := range newBugs {
-		if b.ID > lastID {
-			lastID = b.ID
+	for i, issue := range newIssues {
+		if issue.ID > lastID {
+			lastID = issue.ID
 		}
-		ids = append(ids, fmt.Sprintf("<https://bugzilla.redhat.com/show_bug.cgi?id=%d|#%d>", b.ID, b.ID))
+		ids = append(ids, fmt.Sprintf("<%s|#%d>", issue.URL, issue.ID))
 		if i > 50 {
-			ids = append(ids, fmt.Sprintf(" ... and %d more", len(newBugs)-50))
+			ids = append(ids, fmt.Sprintf(" ... and %d more", len(newIssues)-50))
 			break
 		}
+
+		summary := c.bisectionSummary(ctx, trackerClient, issue)
+		if err := c.notifyAssignee(ctx, slackClient, issue, summary); err != nil {
+			syncCtx.Recorder().Warningf("DeliveryFailed", "Failed to notify assignee of bug #%d: %v", issue.ID, err)
+			errs = append(errs, err)
+		}
 	}
 	slackClient.MessageAdminChannel(fmt.Sprintf("Found new bugs: %s", strings.Join(ids, ", ")))
 
-	// TODO: add interactivity and send to assignee
-
 	return errorutil.NewAggregate(errs)
 }
 
-func Report(ctx context.Context, client cache.BugzillaClient, components []string) (string, error) {
-	newBugs, err := getNewBugs(client, components, 0)
+// Bugs returns the new issues of the last day for components, unformatted,
+// for callers such as pkg/operator/web that render their own view rather
+// than a Slack message.
+func Bugs(client tracker.Client, components []string) ([]tracker.Issue, error) {
+	return getNewIssues(client, components, 0)
+}
+
+func Report(ctx context.Context, client tracker.Client, components []string) (string, error) {
+	newIssues, err := getNewIssues(client, components, 0)
 	if err != nil {
 		return "", err
 	}
 
 	lines := []string{"New bugs of the last week (excluding those already in a different state):", ""}
-	for i, b := range newBugs {
-		lines = append(lines, fmt.Sprintf("> %s", bugutil.FormatBugMessage(*b)))
+	for i, issue := range newIssues {
+		lines = append(lines, fmt.Sprintf("> <%s|#%d> %s", issue.URL, issue.ID, issue.Summary))
 		if i > 20 {
-			lines = append(lines, fmt.Sprintf(" ... and %d more", len(newBugs)-20))
+			lines = append(lines, fmt.Sprintf(" ... and %d more", len(newIssues)-20))
 			break
 		}
 	}
@@ -99,31 +137,228 @@ func Report(ctx context.Context, client cache.BugzillaClient, components []strin
 	return strings.Join(lines, "\n"), nil
 }
 
-func getNewBugs(client cache.BugzillaClient, components []string, lastID int) ([]*bugzilla.Bug, error) {
-	aq := bugzilla.AdvancedQuery{
-		Field: "bug_id",
-		Op:    "greaterthan",
-		Value: strconv.Itoa(lastID),
+func getNewIssues(client tracker.Client, components []string, lastID int) ([]tracker.Issue, error) {
+	q := tracker.Query{
+		Statuses:      []string{"NEW"},
+		Components:    components,
+		IDGreaterThan: lastID,
 	}
 	if lastID == 0 {
-		aq = bugzilla.AdvancedQuery{
-			Field: "creation_ts",
-			Op:    "greaterthaneq",
-			Value: "-24h", // last day
-		}
+		q.CreatedSince = "-24h" // last day
+	}
+	return client.Search(q)
+}
+
+// Action names used both as the Slack interactive-message callback values and
+// as the keys dispatched to HandleAction.
+const (
+	ActionAcknowledge    = "new-bug-ack"
+	ActionPriorityHigh   = "new-bug-priority-high"
+	ActionNeedinfo       = "new-bug-needinfo"
+	ActionNotMyBug       = "new-bug-not-mine"
+	unccStateKeyPrefix   = "new-bug-reporter.uncc-"
+	unccAllComponentsKey = "*"
+)
+
+// notifyAssignee DMs the bug's assignee with an interactive message offering
+// Acknowledge / Set Priority=high / Needinfo requester / Not my bug actions,
+// unless the assignee has opted out via `/bug uncc` for this bug's component(s). If
+// summary (from bisectionSummary) is non-empty, the DM also includes the
+// bisected regression range.
+func (c *NewBugReporter) notifyAssignee(ctx context.Context, slackClient slack.ChannelClient, issue tracker.Issue, summary string) error {
+	if len(issue.AssignedTo) == 0 {
+		return nil
+	}
+	if silenced, err := c.isUncced(ctx, issue.AssignedTo, issue.Component...); err != nil {
+		return err
+	} else if silenced {
+		return nil
+	}
+
+	message := fmt.Sprintf("New bug assigned to you: <%s|#%d> %s", issue.URL, issue.ID, issue.Summary)
+	if len(summary) > 0 {
+		message += "\n\n" + summary
 	}
 
-	return client.Search(bugzilla.Query{
-		Classification: []string{"Red Hat"},
-		Product:        []string{"OpenShift Container Platform"},
-		Status:         []string{"NEW"},
-		Component:      components,
-		Advanced:       []bugzilla.AdvancedQuery{aq},
-		IncludeFields: []string{
-			"id",
-			"assigned_to",
-			"component",
-			"summary",
+	return slackClient.MessageEmailWithActions(issue.AssignedTo, message, slack.CallbackActions{
+		CallbackID: fmt.Sprintf("new-bug-%d", issue.ID),
+		Actions: []slack.Action{
+			{Name: ActionAcknowledge, Text: "Acknowledge", Value: strconv.Itoa(issue.ID)},
+			{Name: ActionPriorityHigh, Text: "Set Priority=high", Value: strconv.Itoa(issue.ID)},
+			{Name: ActionNeedinfo, Text: "Needinfo requester", Value: strconv.Itoa(issue.ID)},
+			{Name: ActionNotMyBug, Text: "Not my bug", Value: strconv.Itoa(issue.ID)},
 		},
 	})
 }
+
+// bisectionSummary bisects the last-known-good/current-broken build versions
+// recorded on issue's whiteboard (e.g. "bisect_good=4.12.3 bisect_bad=4.12.9")
+// down to the narrowest adjacent release range and the component-version
+// diff between them, posts that as a tracker comment, and returns the same
+// text for inclusion in the assignee's Slack ping. It returns "" if issue has
+// no bisection versions recorded, or if bisection fails.
+func (c *NewBugReporter) bisectionSummary(ctx context.Context, client tracker.Client, issue tracker.Issue) string {
+	good, bad, ok := parseBisectionVersions(issue)
+	if !ok || len(issue.Component) == 0 {
+		return ""
+	}
+
+	rng, commits, err := c.bisector.Bisect(ctx, issue.Component[0], good, bad)
+	if err != nil {
+		klog.Warningf("Bisection failed for bug #%d: %v", issue.ID, err)
+		return ""
+	}
+
+	lines := []string{fmt.Sprintf("First bad build likely in %s..%s. Component versions that changed:", rng.Good, rng.Bad)}
+	for _, commit := range commits {
+		lines = append(lines, fmt.Sprintf("> %s: %s -> %s", commit.Component, commit.From, commit.To))
+	}
+	summary := strings.Join(lines, "\n")
+
+	if err := client.AddComment(issue.ID, summary); err != nil {
+		klog.Warningf("Failed to post bisection comment on bug #%d: %v", issue.ID, err)
+	}
+
+	return summary
+}
+
+// parseBisectionVersions extracts the last-known-good and current-broken
+// build versions a CI job recorded on the bug's whiteboard so bisectionSummary
+// can attempt a Bisect before the assignee is DMed.
+func parseBisectionVersions(issue tracker.Issue) (good, bad bisect.Version, ok bool) {
+	for _, field := range strings.Fields(issue.Whiteboard) {
+		if strings.HasPrefix(field, "bisect_good=") {
+			good = bisect.Version(strings.TrimPrefix(field, "bisect_good="))
+		}
+		if strings.HasPrefix(field, "bisect_bad=") {
+			bad = bisect.Version(strings.TrimPrefix(field, "bisect_bad="))
+		}
+	}
+	return good, bad, len(good) > 0 && len(bad) > 0
+}
+
+// ActionHandler is the per-action callback signature notifyAssignee's button
+// names dispatch to.
+type ActionHandler func(ctx context.Context, bugID int, req slacker.Request, w slacker.ResponseWriter) error
+
+// Actions returns the new-bug-* action name to handler table, for
+// registration with slacker's interactive-message callback dispatcher.
+func (c *NewBugReporter) Actions() map[string]ActionHandler {
+	bind := func(action string) ActionHandler {
+		return func(ctx context.Context, bugID int, req slacker.Request, w slacker.ResponseWriter) error {
+			return c.HandleAction(ctx, action, bugID, req, w)
+		}
+	}
+	return map[string]ActionHandler{
+		ActionAcknowledge:  bind(ActionAcknowledge),
+		ActionPriorityHigh: bind(ActionPriorityHigh),
+		ActionNeedinfo:     bind(ActionNeedinfo),
+		ActionNotMyBug:     bind(ActionNotMyBug),
+	}
+}
+
+// HandleAction applies the tracker change corresponding to a button click
+// from notifyAssignee's interactive message; Acknowledge only replies and
+// changes nothing in the tracker. Actions registers this as the
+// callback handler for the new-bug-* action names via slacker.
+func (c *NewBugReporter) HandleAction(ctx context.Context, action string, bugID int, req slacker.Request, w slacker.ResponseWriter) error {
+	client := bugzillatracker.New(c.NewBugzillaClient(ctx))
+
+	switch action {
+	case ActionAcknowledge:
+		w.Reply(fmt.Sprintf("Thanks, bug #%d acknowledged.", bugID))
+		return nil
+	case ActionPriorityHigh:
+		if err := client.SetPriority(bugID, "high"); err != nil {
+			return err
+		}
+		w.Reply(fmt.Sprintf("Bug #%d priority set to high.", bugID))
+		return nil
+	case ActionNeedinfo:
+		if err := client.SetNeedinfo(bugID); err != nil {
+			return err
+		}
+		w.Reply(fmt.Sprintf("Bug #%d set to needinfo.", bugID))
+		return nil
+	case ActionNotMyBug:
+		if err := client.AddComment(bugID, "Reassigning, this is not my bug."); err != nil {
+			return err
+		}
+		w.Reply(fmt.Sprintf("Thanks, flagged bug #%d for reassignment.", bugID))
+		return nil
+	}
+
+	return fmt.Errorf("unknown action %q for bug #%d", action, bugID)
+}
+
+// UnccCommand wraps HandleUnccCommand through pkg/operator.Auth, so
+// `/bug uncc <component>` is routed through the same identity-resolution and
+// allow-list check every other slash command uses, and returns the handler
+// a slacker command router registers.
+func (c *NewBugReporter) UnccCommand() func(req slacker.Request, w slacker.ResponseWriter) {
+	return operator.Auth(c.config, c.HandleUnccCommand)
+}
+
+// HandleUnccCommand implements the `/bug uncc <component>` slash command,
+// silencing further new-bug DMs for the requesting user. An empty component
+// silences all components this reporter watches, borrowing syzkaller's
+// "#syz uncc" idea.
+func (c *NewBugReporter) HandleUnccCommand(req slacker.Request, w slacker.ResponseWriter) {
+	ctx := context.Background()
+	component := strings.TrimSpace(req.Param("component"))
+
+	user, err := operator.ResolveUser(c.config, req, w)
+	if err != nil {
+		w.Reply(fmt.Sprintf("Could not resolve your Bugzilla identity: %v", err))
+		klog.Error(err)
+		return
+	}
+
+	if err := c.uncc(ctx, user, component); err != nil {
+		w.Reply(fmt.Sprintf("Failed to update your opt-out: %v", err))
+		return
+	}
+
+	if len(component) == 0 {
+		w.Reply("You will no longer receive new-bug DMs from me.")
+		return
+	}
+	w.Reply(fmt.Sprintf("You will no longer receive new-bug DMs for component %q.", component))
+}
+
+func (c *NewBugReporter) uncc(ctx context.Context, user, component string) error {
+	key := unccStateKeyPrefix + user
+	v, err := c.GetPersistentValue(ctx, key)
+	if err != nil {
+		return err
+	}
+	silenced := sets.NewString()
+	if len(v) > 0 {
+		silenced.Insert(strings.Split(v, ",")...)
+	}
+	if len(component) == 0 {
+		component = unccAllComponentsKey
+	}
+	silenced.Insert(component)
+	return c.SetPersistentValue(ctx, key, strings.Join(silenced.List(), ","))
+}
+
+func (c *NewBugReporter) isUncced(ctx context.Context, user string, components ...string) (bool, error) {
+	v, err := c.GetPersistentValue(ctx, unccStateKeyPrefix+user)
+	if err != nil {
+		return false, err
+	}
+	if len(v) == 0 {
+		return false, nil
+	}
+	silenced := sets.NewString(strings.Split(v, ",")...)
+	if silenced.Has(unccAllComponentsKey) {
+		return true, nil
+	}
+	for _, comp := range components {
+		if silenced.Has(comp) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,40 @@
+package reporting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAckDuration parses the duration suffix of an "ack <duration>" reply,
+// e.g. "ack 7d" -> 7*24h. A bare "ack" or "silence" reply returns 0, which
+// Store.Acknowledge treats as an indefinite silence.
+func ParseAckDuration(reply string) (time.Duration, error) {
+	fields := strings.Fields(strings.TrimSpace(reply))
+	if len(fields) < 2 {
+		return 0, nil
+	}
+
+	spec := fields[1]
+	if len(spec) < 2 {
+		return 0, fmt.Errorf("invalid ack duration %q", spec)
+	}
+
+	unit := spec[len(spec)-1]
+	n, err := strconv.Atoi(spec[:len(spec)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid ack duration %q: %w", spec, err)
+	}
+
+	switch unit {
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid ack duration unit %q", string(unit))
+	}
+}
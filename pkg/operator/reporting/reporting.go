@@ -0,0 +1,235 @@
+// Package reporting provides a per-bug notification-state store shared by the
+// blockers, escalation and new-bug reporters. It exists so that a reporter's
+// sync loop can decide whether a bug is due for a (re-)notification instead
+// of re-sending the same Slack message on every schedule tick, modeled on
+// syzkaller's reportingPoll/incomingCommand state machine.
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+)
+
+// Stage tracks how far a bug has escalated through the notification cadence.
+type Stage int
+
+const (
+	// StageNone means the bug has never been reported.
+	StageNone Stage = iota
+	// StageFirstPing means the assignee was DMed once.
+	StageFirstPing
+	// StageWeeklyReminder means the bug is still open and being re-pinged weekly.
+	StageWeeklyReminder
+	// StageLeadEscalation means the bug has been escalated to the component lead.
+	StageLeadEscalation
+)
+
+// NextStage returns the stage a bug should advance to after being notified
+// while at current. StageNone advances to StageFirstPing; everything else
+// short of the terminal StageLeadEscalation settles into the steady
+// StageWeeklyReminder cadence. Reporters that escalate straight to a lead
+// (e.g. the escalation reporter) pass StageLeadEscalation to Notified
+// directly rather than going through NextStage.
+func NextStage(current Stage) Stage {
+	switch current {
+	case StageNone:
+		return StageFirstPing
+	case StageLeadEscalation:
+		return StageLeadEscalation
+	default:
+		return StageWeeklyReminder
+	}
+}
+
+// Cadence gives the reminder interval for each non-initial stage a bug can
+// be in, e.g. {StageWeeklyReminder: 7 * 24 * time.Hour}. A stage with no
+// entry falls back to the longest configured interval, so a reporter only
+// needs to configure the stages it actually escalates through.
+type Cadence map[Stage]time.Duration
+
+func (c Cadence) forStage(stage Stage) time.Duration {
+	if d, ok := c[stage]; ok {
+		return d
+	}
+	var longest time.Duration
+	for _, d := range c {
+		if d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// BugState is the persisted notification state of a single bug, keyed by
+// reporter name and bug ID.
+type BugState struct {
+	BugID         int       `json:"bugID"`
+	Reporter      string    `json:"reporter"`
+	Stage         Stage     `json:"stage"`
+	LastReported  time.Time `json:"lastReported"`
+	Acknowledged  bool      `json:"acknowledged"`
+	SilencedUntil time.Time `json:"silencedUntil,omitempty"`
+}
+
+// dueAt returns when this state should next be (re-)reported, given cadence
+// to look up the interval for the state's current stage.
+func (s BugState) dueAt(cadence Cadence) time.Time {
+	if s.LastReported.IsZero() {
+		return time.Time{}
+	}
+	return s.LastReported.Add(cadence.forStage(s.Stage))
+}
+
+// Store persists BugState through the same persistent-value mechanism the
+// reporters already use for their own cursor state.
+type Store struct {
+	ctx controller.ControllerContext
+}
+
+// NewStore returns a Store backed by ctx's persistent-value storage.
+func NewStore(ctx controller.ControllerContext) *Store {
+	return &Store{ctx: ctx}
+}
+
+func stateKey(reporter string, bugID int) string {
+	return fmt.Sprintf("reporting.%s.%d", reporter, bugID)
+}
+
+// Get returns the current state for a bug, or a zero-value StageNone state if
+// it has never been reported.
+func (s *Store) Get(ctx context.Context, reporter string, bugID int) (BugState, error) {
+	raw, err := s.ctx.GetPersistentValue(ctx, stateKey(reporter, bugID))
+	if err != nil {
+		return BugState{}, err
+	}
+	if len(raw) == 0 {
+		return BugState{Reporter: reporter, BugID: bugID}, nil
+	}
+
+	var state BugState
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return BugState{}, fmt.Errorf("corrupt reporting state for %s/%d: %w", reporter, bugID, err)
+	}
+	return state, nil
+}
+
+// Save persists state.
+func (s *Store) Save(ctx context.Context, state BugState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.ctx.SetPersistentValue(ctx, stateKey(state.Reporter, state.BugID), string(raw))
+}
+
+// Due reports whether bug is due for a (re-)notification right now, given
+// cadence for repeat reminders at each stage, and returns its current state
+// for the caller to inspect (e.g. to compute the next stage to pass to
+// Notified). A silenced or acknowledged-and-not-yet-due bug is never due; a
+// bug acknowledged for a duration is due again as soon as that duration has
+// passed. A bug that has never been reported (StageNone) is always due,
+// regardless of cadence.
+func (s *Store) Due(ctx context.Context, reporter string, bugID int, now time.Time, cadence Cadence) (bool, BugState, error) {
+	state, err := s.Get(ctx, reporter, bugID)
+	if err != nil {
+		return false, state, err
+	}
+
+	if !state.SilencedUntil.IsZero() && now.Before(state.SilencedUntil) {
+		return false, state, nil
+	}
+	if state.Acknowledged && state.SilencedUntil.IsZero() {
+		return false, state, nil
+	}
+	if state.Acknowledged {
+		// The acknowledgement's silence window has passed.
+		return true, state, nil
+	}
+	if state.Stage == StageNone {
+		return true, state, nil
+	}
+	return !now.Before(state.dueAt(cadence)), state, nil
+}
+
+// Notified records that bug was just (re-)notified at stage, advancing the
+// stage and bumping LastReported to now, and clears any acknowledgement.
+func (s *Store) Notified(ctx context.Context, reporter string, bugID int, now time.Time, stage Stage) error {
+	state, err := s.Get(ctx, reporter, bugID)
+	if err != nil {
+		return err
+	}
+	state.Reporter = reporter
+	state.BugID = bugID
+	state.Stage = stage
+	state.LastReported = now
+	state.Acknowledged = false
+	return s.Save(ctx, state)
+}
+
+// Acknowledge silences further notifications for bug until now+silenceFor,
+// recording the acknowledgement. silenceFor == 0 silences indefinitely: Due
+// does not report the bug as due again until a later Notified call clears
+// the acknowledgement.
+func (s *Store) Acknowledge(ctx context.Context, reporter string, bugID int, now time.Time, silenceFor time.Duration) error {
+	state, err := s.Get(ctx, reporter, bugID)
+	if err != nil {
+		return err
+	}
+	state.Reporter = reporter
+	state.BugID = bugID
+	state.Acknowledged = true
+	if silenceFor > 0 {
+		state.SilencedUntil = now.Add(silenceFor)
+	}
+	return s.Save(ctx, state)
+}
+
+// Silence is an alias for Acknowledge(..., 0), used for a bare "silence"
+// reply that doesn't specify a duration.
+func (s *Store) Silence(ctx context.Context, reporter string, bugID int, now time.Time) error {
+	return s.Acknowledge(ctx, reporter, bugID, now, 0)
+}
+
+// ReplyRouter is the subset of a Slack thread-reply router a reporter needs
+// in order to make HandleReply reachable from a real thread reply, so
+// registration happens once at construction instead of being left for some
+// caller to remember to wire up. It mirrors pkg/operator/reporters/new's
+// CommandRouter.
+type ReplyRouter interface {
+	// ThreadReply registers handler to be called whenever a user replies in a
+	// thread reporter started, with the bug ID threaded through from the
+	// original notification.
+	ThreadReply(reporter string, handler func(ctx context.Context, bugID int, reply string) error)
+}
+
+// HandleReply applies a free-text "ack [duration]" or "silence" reply to
+// bug's reporting state, e.g. "ack 7d" silences reminders for a week and a
+// bare "silence" silences it indefinitely (see Acknowledge). It is the single
+// implementation behind every reporter's Slack thread-reply handler, so
+// "ack"/"silence" parsing and semantics stay consistent across reporters.
+func (s *Store) HandleReply(ctx context.Context, reporter string, bugID int, reply string) error {
+	now := time.Now()
+
+	fields := strings.Fields(strings.TrimSpace(reply))
+	if len(fields) == 0 {
+		return fmt.Errorf("empty reply")
+	}
+
+	switch fields[0] {
+	case "ack":
+		d, err := ParseAckDuration(reply)
+		if err != nil {
+			return err
+		}
+		return s.Acknowledge(ctx, reporter, bugID, now, d)
+	case "silence":
+		return s.Silence(ctx, reporter, bugID, now)
+	default:
+		return fmt.Errorf("unrecognized reply %q, expected \"ack [duration]\" or \"silence\"", reply)
+	}
+}
@@ -0,0 +1,184 @@
+package reporting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/controller"
+)
+
+// fakeControllerContext embeds the (unimplemented) controller.ControllerContext
+// interface so it satisfies Store's dependency, but only GetPersistentValue
+// and SetPersistentValue - the two methods Store actually calls - are given
+// real implementations, backed by an in-memory map standing in for the
+// operator's real persistent storage.
+type fakeControllerContext struct {
+	controller.ControllerContext
+	values map[string]string
+}
+
+func newFakeControllerContext() *fakeControllerContext {
+	return &fakeControllerContext{values: map[string]string{}}
+}
+
+func (f *fakeControllerContext) GetPersistentValue(ctx context.Context, key string) (string, error) {
+	return f.values[key], nil
+}
+
+func (f *fakeControllerContext) SetPersistentValue(ctx context.Context, key, value string) error {
+	f.values[key] = value
+	return nil
+}
+
+func TestStoreDue(t *testing.T) {
+	cadence := Cadence{
+		StageFirstPing:      7 * 24 * time.Hour,
+		StageWeeklyReminder: 7 * 24 * time.Hour,
+	}
+	now := time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC)
+
+	t.Run("never reported bug is always due", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		due, state, err := store.Due(context.Background(), "blockers", 1, now, cadence)
+		if err != nil {
+			t.Fatalf("Due returned error: %v", err)
+		}
+		if !due {
+			t.Error("expected an unreported bug to be due")
+		}
+		if state.Stage != StageNone {
+			t.Errorf("stage = %v, want StageNone", state.Stage)
+		}
+	})
+
+	t.Run("freshly notified bug is not due", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		ctx := context.Background()
+		if err := store.Notified(ctx, "blockers", 2, now, StageFirstPing); err != nil {
+			t.Fatalf("Notified returned error: %v", err)
+		}
+		due, _, err := store.Due(ctx, "blockers", 2, now.Add(time.Hour), cadence)
+		if err != nil {
+			t.Fatalf("Due returned error: %v", err)
+		}
+		if due {
+			t.Error("expected a just-notified bug to not be due yet")
+		}
+	})
+
+	t.Run("bug becomes due again after its stage's cadence elapses", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		ctx := context.Background()
+		if err := store.Notified(ctx, "blockers", 3, now, StageFirstPing); err != nil {
+			t.Fatalf("Notified returned error: %v", err)
+		}
+		due, _, err := store.Due(ctx, "blockers", 3, now.Add(8*24*time.Hour), cadence)
+		if err != nil {
+			t.Fatalf("Due returned error: %v", err)
+		}
+		if !due {
+			t.Error("expected bug to be due again after its cadence elapsed")
+		}
+	})
+
+	t.Run("acknowledged bug with no duration is never due", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		ctx := context.Background()
+		if err := store.Notified(ctx, "blockers", 4, now, StageFirstPing); err != nil {
+			t.Fatalf("Notified returned error: %v", err)
+		}
+		if err := store.Acknowledge(ctx, "blockers", 4, now, 0); err != nil {
+			t.Fatalf("Acknowledge returned error: %v", err)
+		}
+		due, _, err := store.Due(ctx, "blockers", 4, now.Add(365*24*time.Hour), cadence)
+		if err != nil {
+			t.Fatalf("Due returned error: %v", err)
+		}
+		if due {
+			t.Error("expected an indefinitely acknowledged bug to never become due")
+		}
+	})
+
+	t.Run("acknowledged bug becomes due again once its silence expires", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		ctx := context.Background()
+		if err := store.Notified(ctx, "blockers", 5, now, StageFirstPing); err != nil {
+			t.Fatalf("Notified returned error: %v", err)
+		}
+		if err := store.Acknowledge(ctx, "blockers", 5, now, 24*time.Hour); err != nil {
+			t.Fatalf("Acknowledge returned error: %v", err)
+		}
+		due, _, err := store.Due(ctx, "blockers", 5, now.Add(48*time.Hour), cadence)
+		if err != nil {
+			t.Fatalf("Due returned error: %v", err)
+		}
+		if !due {
+			t.Error("expected bug to become due again once its silence window passed")
+		}
+	})
+}
+
+func TestNextStage(t *testing.T) {
+	tests := []struct {
+		current Stage
+		want    Stage
+	}{
+		{StageNone, StageFirstPing},
+		{StageFirstPing, StageWeeklyReminder},
+		{StageWeeklyReminder, StageWeeklyReminder},
+		{StageLeadEscalation, StageLeadEscalation},
+	}
+	for _, tt := range tests {
+		if got := NextStage(tt.current); got != tt.want {
+			t.Errorf("NextStage(%v) = %v, want %v", tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestStoreHandleReply(t *testing.T) {
+	now := time.Now()
+
+	t.Run("ack with duration silences until it elapses", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		ctx := context.Background()
+		if err := store.HandleReply(ctx, "blockers", 1, "ack 7d"); err != nil {
+			t.Fatalf("HandleReply returned error: %v", err)
+		}
+		state, err := store.Get(ctx, "blockers", 1)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if !state.Acknowledged {
+			t.Error("expected bug to be acknowledged")
+		}
+		if !state.SilencedUntil.After(now) {
+			t.Errorf("SilencedUntil = %v, want a time after %v", state.SilencedUntil, now)
+		}
+	})
+
+	t.Run("bare silence acknowledges indefinitely", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		ctx := context.Background()
+		if err := store.HandleReply(ctx, "blockers", 2, "silence"); err != nil {
+			t.Fatalf("HandleReply returned error: %v", err)
+		}
+		state, err := store.Get(ctx, "blockers", 2)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if !state.Acknowledged {
+			t.Error("expected bug to be acknowledged")
+		}
+		if !state.SilencedUntil.IsZero() {
+			t.Errorf("SilencedUntil = %v, want zero value", state.SilencedUntil)
+		}
+	})
+
+	t.Run("unrecognized reply is rejected", func(t *testing.T) {
+		store := NewStore(newFakeControllerContext())
+		if err := store.HandleReply(context.Background(), "blockers", 3, "what?"); err == nil {
+			t.Error("expected an error for an unrecognized reply")
+		}
+	})
+}
@@ -0,0 +1,256 @@
+// Package web exposes the same data the blockers, escalation and new-bug
+// Slack reporters compute as HTML pages and JSON endpoints, similar to
+// syzkaller's /, /bug, /admin handlers, so teams can browse current state
+// instead of waiting for the next scheduled Slack ping.
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/cache"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/config"
+	blockers "github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/blockers-new"
+	"github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/escalation"
+	newbugs "github.com/mfojtik/bugzilla-operator/pkg/operator/reporters/new"
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+	bugzillatracker "github.com/mfojtik/bugzilla-operator/pkg/tracker/bugzilla"
+)
+
+// userHeader is set by the reverse proxy the operator already sits behind
+// for Slack OAuth; the web UI piggy-backs on it instead of inventing its own
+// login flow.
+const userHeader = "X-Forwarded-Email"
+
+// Server renders the blockers/escalation/new-bug reporters' data as HTML
+// pages and JSON endpoints.
+type Server struct {
+	config     config.OperatorConfig
+	components []string
+	client     func() cache.BugzillaClient
+}
+
+// NewServer constructs a Server. client is invoked per request so the caller
+// can plug in e.g. the same cache.BugzillaClient the reporters already share.
+func NewServer(cfg config.OperatorConfig, components []string, client func() cache.BugzillaClient) *Server {
+	return &Server{config: cfg, components: components, client: client}
+}
+
+// Handler returns the http.Handler serving all of the Server's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.auth(s.handleIndex))
+	mux.HandleFunc("/component/", s.auth(s.handleComponent))
+	mux.HandleFunc("/assignee/", s.auth(s.handleAssignee))
+	mux.HandleFunc("/lead/", s.auth(s.handleLead))
+	mux.HandleFunc("/escalations", s.auth(s.handleEscalations))
+	return mux
+}
+
+// auth restricts handler to users config.ExpandGroups(s.config.Groups)
+// recognizes, the same group-expansion mechanism pkg/operator/auth.go uses
+// to authorize Slack commands.
+func (s *Server) auth(handler http.HandlerFunc) http.HandlerFunc {
+	users := config.ExpandGroups(s.config.Groups)
+	return func(w http.ResponseWriter, r *http.Request) {
+		email := r.Header.Get(userHeader)
+		if len(email) == 0 || !users.Has(email) {
+			http.Error(w, fmt.Sprintf("Permission denied: %q is not a recognized user", email), http.StatusForbidden)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func wantsJSON(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html><body>
+<h1>Bugzilla operator</h1>
+<ul>
+{{range .Components}}<li><a href="/component/{{.}}">{{.}}</a></li>{{end}}
+</ul>
+<p><a href="/escalations">Escalations</a></p>
+</body></html>`))
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if wantsJSON(r) {
+		writeJSON(w, map[string]interface{}{"components": s.components})
+		return
+	}
+	if err := indexTemplate.Execute(w, map[string]interface{}{"Components": s.components}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// componentData is the JSON/HTML shape for /component/<name>.
+type componentData struct {
+	Component string          `json:"component"`
+	NewBugs   []*trackerIssue `json:"newBugs"`
+	Triage    []*trackerIssue `json:"triage"`
+	Blockers  []*trackerIssue `json:"blockers"`
+	Urgent    []*trackerIssue `json:"urgent"`
+}
+
+type trackerIssue struct {
+	ID         int    `json:"id"`
+	URL        string `json:"url"`
+	Summary    string `json:"summary"`
+	AssignedTo string `json:"assignedTo"`
+}
+
+func toTrackerIssues(issues []tracker.Issue, ids []int) []*trackerIssue {
+	byID := map[int]tracker.Issue{}
+	for _, i := range issues {
+		byID[i.ID] = i
+	}
+	out := make([]*trackerIssue, 0, len(ids))
+	for _, id := range ids {
+		i, ok := byID[id]
+		if !ok {
+			continue
+		}
+		out = append(out, &trackerIssue{ID: i.ID, URL: i.URL, Summary: i.Summary, AssignedTo: i.AssignedTo})
+	}
+	return out
+}
+
+func (s *Server) componentData(component string) (componentData, error) {
+	trackerClient := bugzillatracker.New(s.client())
+
+	summary, issues, err := blockers.AllData(trackerClient, &s.config, []string{component})
+	if err != nil {
+		return componentData{}, err
+	}
+
+	newIssues, err := newbugs.Bugs(trackerClient, []string{component})
+	if err != nil {
+		return componentData{}, err
+	}
+
+	return componentData{
+		Component: component,
+		NewBugs:   toTrackerIssues(newIssues, issueIDs(newIssues)),
+		Triage:    toTrackerIssues(issues, summary.ToTriageIDs()),
+		Blockers:  toTrackerIssues(issues, summary.BlockerPlusIDs()),
+		Urgent:    toTrackerIssues(issues, summary.UrgentIDs()),
+	}, nil
+}
+
+// issueIDs extracts the IDs of issues, for passing back into toTrackerIssues.
+func issueIDs(issues []tracker.Issue) []int {
+	ids := make([]int, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func (s *Server) handleComponent(w http.ResponseWriter, r *http.Request) {
+	component := strings.TrimPrefix(r.URL.Path, "/component/")
+	if len(component) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := s.componentData(component)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, data)
+		return
+	}
+	fmt.Fprintf(w, "<h1>%s</h1><p>%d new, %d to triage, %d blocker+, %d urgent</p>",
+		template.HTMLEscapeString(data.Component), len(data.NewBugs), len(data.Triage), len(data.Blockers), len(data.Urgent))
+}
+
+// handleAssignee renders the bugs in s.components assigned to the email in
+// the path, across the same buckets the blockers reporter DMs about.
+func (s *Server) handleAssignee(w http.ResponseWriter, r *http.Request) {
+	email := strings.TrimPrefix(r.URL.Path, "/assignee/")
+	if len(email) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	trackerClient := bugzillatracker.New(s.client())
+	summary, issues, err := blockers.AllData(trackerClient, &s.config, s.components)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	assigned := func(ids []int) []*trackerIssue {
+		var out []*trackerIssue
+		for _, issue := range toTrackerIssues(issues, ids) {
+			if issue.AssignedTo == email {
+				out = append(out, issue)
+			}
+		}
+		return out
+	}
+
+	data := map[string]interface{}{
+		"email":    email,
+		"triage":   assigned(summary.ToTriageIDs()),
+		"blockers": assigned(summary.BlockerPlusIDs()),
+		"urgent":   assigned(summary.UrgentIDs()),
+	}
+	writeJSON(w, data)
+}
+
+// handleLead renders the escalated bugs assigned to the lead's teams, the
+// same grouping the escalation reporter posts to Slack.
+func (s *Server) handleLead(w http.ResponseWriter, r *http.Request) {
+	lead := strings.TrimPrefix(r.URL.Path, "/lead/")
+	if len(lead) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := escalation.AllData(bugzillatracker.New(s.client()), &s.config, s.components)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"lead": lead,
+		"bugs": data.LeadsBugs[lead],
+	})
+}
+
+func (s *Server) handleEscalations(w http.ResponseWriter, r *http.Request) {
+	data, err := escalation.AllData(bugzillatracker.New(s.client()), &s.config, s.components)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	leads := make([]string, 0, len(data.LeadsBugs))
+	for lead := range data.LeadsBugs {
+		leads = append(leads, lead)
+	}
+	sort.Strings(leads)
+
+	writeJSON(w, map[string]interface{}{
+		"leads":    leads,
+		"silenced": data.Silenced,
+	})
+}
@@ -0,0 +1,129 @@
+// Package bugzilla adapts the existing github.com/eparis/bugzilla client to
+// the tracker.Client interface, so reporters written against tracker.Issue
+// keep talking to bugzilla.redhat.com exactly as before.
+package bugzilla
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/eparis/bugzilla"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/cache"
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+)
+
+const (
+	classification = "Red Hat"
+	product        = "OpenShift Container Platform"
+)
+
+type client struct {
+	bz cache.BugzillaClient
+}
+
+// New wraps an existing Bugzilla client as a tracker.Client.
+func New(bz cache.BugzillaClient) tracker.Client {
+	return &client{bz: bz}
+}
+
+// buildQuery translates q into a bugzilla.Query, kept separate from Search
+// so its field-mapping logic can be unit tested without a live Bugzilla
+// client.
+func buildQuery(q tracker.Query) bugzilla.Query {
+	bq := bugzilla.Query{
+		Classification: []string{classification},
+		Product:        []string{product},
+		Status:         q.Statuses,
+		Component:      q.Components,
+		TargetRelease:  q.TargetRelease,
+		IncludeFields: []string{
+			"id",
+			"assigned_to",
+			"component",
+			"keywords",
+			"status",
+			"resolution",
+			"summary",
+			"changeddate",
+			"severity",
+			"priority",
+			"target_release",
+			"whiteboard",
+			"flags",
+			"escalation",
+			"external_bugs",
+		},
+	}
+
+	if q.ExcludeLowPrioritySeverity {
+		bq.Advanced = append(bq.Advanced,
+			bugzilla.AdvancedQuery{Field: "bug_severity", Op: "notequals", Value: "low"},
+			bugzilla.AdvancedQuery{Field: "priority", Op: "notequals", Value: "low"},
+		)
+	}
+	if q.IDGreaterThan > 0 {
+		bq.Advanced = append(bq.Advanced, bugzilla.AdvancedQuery{Field: "bug_id", Op: "greaterthan", Value: strconv.Itoa(q.IDGreaterThan)})
+	} else if len(q.CreatedSince) > 0 {
+		bq.Advanced = append(bq.Advanced, bugzilla.AdvancedQuery{Field: "creation_ts", Op: "greaterthaneq", Value: q.CreatedSince})
+	}
+	return bq
+}
+
+func (c *client) Search(q tracker.Query) ([]tracker.Issue, error) {
+	bugs, err := c.bz.Search(buildQuery(q))
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]tracker.Issue, 0, len(bugs))
+	for _, b := range bugs {
+		issues = append(issues, convert(b))
+	}
+	return issues, nil
+}
+
+func (c *client) SetPriority(id int, priority string) error {
+	return c.bz.UpdateBug(id, bugzilla.BugUpdate{Priority: priority})
+}
+
+func (c *client) SetNeedinfo(id int) error {
+	return c.bz.UpdateBug(id, bugzilla.BugUpdate{NeedInfo: true})
+}
+
+func (c *client) AddComment(id int, body string) error {
+	return c.bz.UpdateBug(id, bugzilla.BugUpdate{Comment: &bugzilla.BugComment{Body: body}})
+}
+
+func (c *client) Link(id int) string {
+	return fmt.Sprintf("https://bugzilla.redhat.com/show_bug.cgi?id=%d", id)
+}
+
+func convert(b *bugzilla.Bug) tracker.Issue {
+	flags := make([]tracker.Flag, 0, len(b.Flags))
+	for _, f := range b.Flags {
+		flags = append(flags, tracker.Flag{Name: f.Name, Value: f.Status})
+	}
+
+	links := make([]tracker.ExternalLink, 0, len(b.ExternalBugs))
+	for _, eb := range b.ExternalBugs {
+		links = append(links, tracker.ExternalLink{System: eb.Type.Type, ID: eb.ExtBzBugID})
+	}
+
+	return tracker.Issue{
+		ID:            b.ID,
+		URL:           fmt.Sprintf("https://bugzilla.redhat.com/show_bug.cgi?id=%d", b.ID),
+		Summary:       b.Summary,
+		Status:        b.Status,
+		AssignedTo:    b.AssignedTo,
+		Component:     b.Component,
+		Keywords:      b.Keywords,
+		Whiteboard:    b.Whiteboard,
+		Severity:      b.Severity,
+		Priority:      b.Priority,
+		TargetRelease: b.TargetRelease,
+		Flags:         flags,
+		Escalated:     b.Escalation == "Yes",
+		ExternalLinks: links,
+	}
+}
@@ -0,0 +1,80 @@
+package bugzilla
+
+import (
+	"testing"
+
+	"github.com/eparis/bugzilla"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+)
+
+func TestBuildQuery(t *testing.T) {
+	t.Run("excluding low priority/severity adds both advanced filters", func(t *testing.T) {
+		bq := buildQuery(tracker.Query{ExcludeLowPrioritySeverity: true})
+		if len(bq.Advanced) != 2 {
+			t.Fatalf("Advanced = %+v, want 2 entries", bq.Advanced)
+		}
+	})
+
+	t.Run("id cursor takes precedence over created-since", func(t *testing.T) {
+		bq := buildQuery(tracker.Query{IDGreaterThan: 100, CreatedSince: "-24h"})
+		if len(bq.Advanced) != 1 || bq.Advanced[0].Field != "bug_id" {
+			t.Errorf("Advanced = %+v, want a single bug_id filter", bq.Advanced)
+		}
+	})
+
+	t.Run("created-since is used when there is no id cursor", func(t *testing.T) {
+		bq := buildQuery(tracker.Query{CreatedSince: "-24h"})
+		if len(bq.Advanced) != 1 || bq.Advanced[0].Field != "creation_ts" {
+			t.Errorf("Advanced = %+v, want a single creation_ts filter", bq.Advanced)
+		}
+	})
+
+	t.Run("statuses and components pass through unchanged", func(t *testing.T) {
+		bq := buildQuery(tracker.Query{Statuses: []string{"NEW"}, Components: []string{"etcd"}})
+		if len(bq.Status) != 1 || bq.Status[0] != "NEW" {
+			t.Errorf("Status = %v, want [NEW]", bq.Status)
+		}
+		if len(bq.Component) != 1 || bq.Component[0] != "etcd" {
+			t.Errorf("Component = %v, want [etcd]", bq.Component)
+		}
+	})
+}
+
+func TestConvert(t *testing.T) {
+	b := &bugzilla.Bug{
+		ID:            123,
+		Summary:       "etcd leader flaps",
+		Status:        "NEW",
+		AssignedTo:    "developer@example.com",
+		Component:     []string{"etcd"},
+		Severity:      "high",
+		Priority:      "high",
+		TargetRelease: []string{"4.12.0"},
+		Escalation:    "Yes",
+		Flags: []bugzilla.Flag{
+			{Name: "blocker", Status: "+"},
+		},
+		ExternalBugs: []bugzilla.ExternalBug{
+			{ExtBzBugID: "00123456", Type: bugzilla.ExternalBugType{Type: "SFDC"}},
+		},
+	}
+
+	got := convert(b)
+
+	if got.ID != 123 {
+		t.Errorf("ID = %d, want 123", got.ID)
+	}
+	if got.URL != "https://bugzilla.redhat.com/show_bug.cgi?id=123" {
+		t.Errorf("URL = %q, want the show_bug URL for 123", got.URL)
+	}
+	if !got.HasFlag("blocker", "+") {
+		t.Errorf("flags = %v, want a blocker:+ flag", got.Flags)
+	}
+	if !got.Escalated {
+		t.Error(`expected Escalation: "Yes" to convert to Escalated: true`)
+	}
+	if len(got.ExternalLinks) != 1 || got.ExternalLinks[0].System != "SFDC" {
+		t.Errorf("ExternalLinks = %+v, want a single SFDC link", got.ExternalLinks)
+	}
+}
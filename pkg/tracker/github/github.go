@@ -0,0 +1,165 @@
+// Package github adapts GitHub Issues to the tracker.Client interface, for
+// components that have moved off Bugzilla onto a GitHub repository. A
+// "target release" maps onto the issue's milestone, and Bugzilla flags map
+// onto labels of the form "<name>:<value>" (e.g. "blocker:+").
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+)
+
+type client struct {
+	gh          *github.Client
+	owner, repo string
+}
+
+// New wraps a GitHub client as a tracker.Client scoped to a single
+// owner/repo, the unit config.OperatorConfig selects a tracker per.
+func New(gh *github.Client, owner, repo string) tracker.Client {
+	return &client{gh: gh, owner: owner, repo: repo}
+}
+
+// buildSearchQuery translates q into a GitHub search query scoped to
+// owner/repo, kept separate from Search so its string-building logic (in
+// particular the OR vs AND label-qualifier distinction) can be unit tested
+// without a live GitHub client.
+func buildSearchQuery(owner, repo string, q tracker.Query) string {
+	query := fmt.Sprintf("repo:%s/%s is:issue is:open", owner, repo)
+	if len(q.Statuses) > 0 {
+		// A single label: qualifier with comma-separated values is an OR in
+		// GitHub's search syntax, unlike one label: per term (which ANDs) -
+		// q.Statuses are alternative Bugzilla workflow states, not labels an
+		// issue would carry all of at once.
+		quoted := make([]string, len(q.Statuses))
+		for i, status := range q.Statuses {
+			quoted[i] = fmt.Sprintf("%q", status)
+		}
+		query += fmt.Sprintf(" label:%s", strings.Join(quoted, ","))
+	}
+	for _, milestone := range q.TargetRelease {
+		query += fmt.Sprintf(" milestone:%q", milestone)
+	}
+	if q.CreatedSince != "" {
+		query += fmt.Sprintf(" created:>=%s", q.CreatedSince)
+	}
+	if q.ExcludeLowPrioritySeverity {
+		query += ` -label:"priority:low" -label:"severity:low"`
+	}
+	return query
+}
+
+func (c *client) Search(q tracker.Query) ([]tracker.Issue, error) {
+	query := buildSearchQuery(c.owner, c.repo, q)
+
+	var issues []tracker.Issue
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := c.gh.Search.Issues(context.Background(), query, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range result.Issues {
+			if q.IDGreaterThan > 0 && issue.GetNumber() <= q.IDGreaterThan {
+				continue
+			}
+			if !matchesComponents(issue, q.Components) {
+				continue
+			}
+			issues = append(issues, convert(issue))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
+// matchesComponents treats Components as the "component:<name>" label
+// convention, the GitHub equivalent of a Bugzilla component.
+func matchesComponents(issue *github.Issue, components []string) bool {
+	if len(components) == 0 {
+		return true
+	}
+	for _, l := range issue.Labels {
+		for _, comp := range components {
+			if l.GetName() == "component:"+comp {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *client) SetPriority(id int, priority string) error {
+	_, _, err := c.gh.Issues.AddLabelsToIssue(context.Background(), c.owner, c.repo, id, []string{"priority:" + priority})
+	return err
+}
+
+func (c *client) SetNeedinfo(id int) error {
+	_, _, err := c.gh.Issues.AddLabelsToIssue(context.Background(), c.owner, c.repo, id, []string{"needinfo"})
+	return err
+}
+
+func (c *client) AddComment(id int, body string) error {
+	_, _, err := c.gh.Issues.CreateComment(context.Background(), c.owner, c.repo, id, &github.IssueComment{Body: &body})
+	return err
+}
+
+func (c *client) Link(id int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", c.owner, c.repo, id)
+}
+
+func convert(issue *github.Issue) tracker.Issue {
+	var component []string
+	var flags []tracker.Flag
+	for _, l := range issue.Labels {
+		name := l.GetName()
+		switch {
+		case strings.HasPrefix(name, "component:"):
+			component = append(component, strings.TrimPrefix(name, "component:"))
+		case strings.Contains(name, ":"):
+			parts := strings.SplitN(name, ":", 2)
+			flags = append(flags, tracker.Flag{Name: parts[0], Value: parts[1]})
+		default:
+			flags = append(flags, tracker.Flag{Name: name, Value: "+"})
+		}
+	}
+
+	var targetRelease []string
+	if m := issue.GetMilestone(); m != nil {
+		targetRelease = []string{m.GetTitle()}
+	}
+
+	assignee := ""
+	if a := issue.GetAssignee(); a != nil {
+		assignee = a.GetLogin()
+	}
+
+	return tracker.Issue{
+		ID:            issue.GetNumber(),
+		URL:           issue.GetHTMLURL(),
+		Summary:       issue.GetTitle(),
+		Status:        issue.GetState(),
+		AssignedTo:    assignee,
+		Component:     component,
+		TargetRelease: targetRelease,
+		Flags:         flags,
+		Escalated:     hasLabel(issue, "escalated"),
+	}
+}
+
+func hasLabel(issue *github.Issue, name string) bool {
+	for _, l := range issue.Labels {
+		if l.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
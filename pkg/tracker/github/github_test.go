@@ -0,0 +1,124 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	gh "github.com/google/go-github/v45/github"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+)
+
+func TestBuildSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   tracker.Query
+		want    []string
+		notWant []string
+	}{
+		{
+			name:  "statuses are OR'd via a single comma-separated label qualifier",
+			query: tracker.Query{Statuses: []string{"NEW", "ASSIGNED"}},
+			want:  []string{`label:"NEW","ASSIGNED"`},
+		},
+		{
+			name:    "statuses never produce one label: term per status",
+			query:   tracker.Query{Statuses: []string{"NEW", "ASSIGNED"}},
+			notWant: []string{`label:"NEW" label:"ASSIGNED"`},
+		},
+		{
+			name:  "excluding low priority/severity appends negated label terms",
+			query: tracker.Query{ExcludeLowPrioritySeverity: true},
+			want:  []string{`-label:"priority:low"`, `-label:"severity:low"`},
+		},
+		{
+			name:  "target releases map onto milestone qualifiers",
+			query: tracker.Query{TargetRelease: []string{"4.12.0"}},
+			want:  []string{`milestone:"4.12.0"`},
+		},
+		{
+			name:  "created-since maps onto a created qualifier",
+			query: tracker.Query{CreatedSince: "-24h"},
+			want:  []string{"created:>=-24h"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSearchQuery("openshift", "origin", tt.query)
+			if !strings.HasPrefix(got, "repo:openshift/origin is:issue is:open") {
+				t.Errorf("query %q missing expected repo/state prefix", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("query %q missing %q", got, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("query %q unexpectedly contains %q", got, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchesComponents(t *testing.T) {
+	issue := &gh.Issue{Labels: []*gh.Label{
+		{Name: gh.String("component:etcd")},
+		{Name: gh.String("priority:high")},
+	}}
+
+	if !matchesComponents(issue, nil) {
+		t.Error("expected no components filter to match everything")
+	}
+	if !matchesComponents(issue, []string{"etcd"}) {
+		t.Error("expected issue with component:etcd label to match")
+	}
+	if matchesComponents(issue, []string{"kube-apiserver"}) {
+		t.Error("expected issue without a matching component label to not match")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	issue := &gh.Issue{
+		Number:  gh.Int(42),
+		HTMLURL: gh.String("https://github.com/openshift/origin/issues/42"),
+		Title:   gh.String("etcd leader flaps"),
+		State:   gh.String("open"),
+		Assignee: &gh.User{
+			Login: gh.String("developer"),
+		},
+		Milestone: &gh.Milestone{Title: gh.String("4.12.0")},
+		Labels: []*gh.Label{
+			{Name: gh.String("component:etcd")},
+			{Name: gh.String("blocker:+")},
+			{Name: gh.String("escalated")},
+			{Name: gh.String("needinfo")},
+		},
+	}
+
+	got := convert(issue)
+
+	if got.ID != 42 {
+		t.Errorf("ID = %d, want 42", got.ID)
+	}
+	if got.AssignedTo != "developer" {
+		t.Errorf("AssignedTo = %q, want %q", got.AssignedTo, "developer")
+	}
+	if len(got.Component) != 1 || got.Component[0] != "etcd" {
+		t.Errorf("Component = %v, want [etcd]", got.Component)
+	}
+	if len(got.TargetRelease) != 1 || got.TargetRelease[0] != "4.12.0" {
+		t.Errorf("TargetRelease = %v, want [4.12.0]", got.TargetRelease)
+	}
+	if !got.HasFlag("blocker", "+") {
+		t.Errorf("flags = %v, want a blocker:+ flag", got.Flags)
+	}
+	if !got.HasFlag("needinfo", "+") {
+		t.Errorf("flags = %v, want a bare needinfo label mapped to needinfo:+", got.Flags)
+	}
+	if !got.Escalated {
+		t.Error("expected issue carrying the escalated label to be Escalated")
+	}
+}
@@ -0,0 +1,162 @@
+// Package jira adapts a Jira project to the tracker.Client interface, for
+// components that have moved off Bugzilla onto Jira. A "target release"
+// maps onto the issue's fixVersion, and Bugzilla flags map onto Jira labels
+// of the form "<name>-<value>", where <value> is "plus", "minus" or
+// "questionmark" (Jira labels cannot contain "+", "-" or "?" themselves).
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	jiralib "github.com/andygrunwald/go-jira"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+)
+
+type client struct {
+	jira    *jiralib.Client
+	baseURL string
+	project string
+}
+
+// New wraps a Jira client as a tracker.Client scoped to a single project.
+func New(j *jiralib.Client, baseURL, project string) tracker.Client {
+	return &client{jira: j, baseURL: baseURL, project: project}
+}
+
+// buildJQL translates q into a JQL query scoped to project, kept separate
+// from Search so its string-building logic can be unit tested without a
+// live Jira client.
+func buildJQL(project string, q tracker.Query) string {
+	jql := fmt.Sprintf("project = %s", project)
+	if len(q.Statuses) > 0 {
+		jql += fmt.Sprintf(" AND status in (%s)", quoteJoin(q.Statuses))
+	}
+	if len(q.Components) > 0 {
+		jql += fmt.Sprintf(" AND component in (%s)", quoteJoin(q.Components))
+	}
+	if len(q.TargetRelease) > 0 {
+		jql += fmt.Sprintf(" AND fixVersion in (%s)", quoteJoin(q.TargetRelease))
+	}
+	if q.CreatedSince != "" {
+		jql += fmt.Sprintf(" AND created >= \"%s\"", q.CreatedSince)
+	}
+	if q.IDGreaterThan > 0 {
+		jql += fmt.Sprintf(" AND key > %s-%d", project, q.IDGreaterThan)
+	}
+	if q.ExcludeLowPrioritySeverity {
+		// Only priority is filtered; severity is not.
+		jql += " AND priority != Low"
+	}
+	return jql
+}
+
+func (c *client) Search(q tracker.Query) ([]tracker.Issue, error) {
+	jql := buildJQL(c.project, q)
+
+	result, _, err := c.jira.Issue.Search(jql, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]tracker.Issue, 0, len(result))
+	for _, issue := range result {
+		issues = append(issues, convert(issue, c.baseURL))
+	}
+	return issues, nil
+}
+
+func quoteJoin(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (c *client) SetPriority(id int, priority string) error {
+	_, err := c.jira.Issue.UpdateIssue(c.key(id), map[string]interface{}{
+		"fields": map[string]interface{}{"priority": map[string]string{"name": priority}},
+	})
+	return err
+}
+
+func (c *client) SetNeedinfo(id int) error {
+	_, _, err := c.jira.Issue.AddLabel(c.key(id), "needinfo")
+	return err
+}
+
+func (c *client) AddComment(id int, body string) error {
+	_, _, err := c.jira.Issue.AddComment(c.key(id), &jiralib.Comment{Body: body})
+	return err
+}
+
+func (c *client) Link(id int) string {
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(c.baseURL, "/"), c.key(id))
+}
+
+func (c *client) key(id int) string {
+	return fmt.Sprintf("%s-%d", c.project, id)
+}
+
+// jiraFlagValues maps the Jira label suffixes convert decodes back onto the
+// tri-state flag values Bugzilla/GitHub flags carry, since Jira labels
+// cannot contain "+", "-" or "?" themselves.
+var jiraFlagValues = map[string]string{
+	"plus":         "+",
+	"minus":        "-",
+	"questionmark": "?",
+}
+
+func convert(issue jiralib.Issue, baseURL string) tracker.Issue {
+	var flags []tracker.Flag
+	for _, label := range issue.Fields.Labels {
+		if parts := strings.SplitN(label, "-", 2); len(parts) == 2 {
+			if value, ok := jiraFlagValues[parts[1]]; ok {
+				flags = append(flags, tracker.Flag{Name: parts[0], Value: value})
+				continue
+			}
+		}
+		flags = append(flags, tracker.Flag{Name: label, Value: "+"})
+	}
+
+	var targetRelease []string
+	for _, v := range issue.Fields.FixVersions {
+		targetRelease = append(targetRelease, v.Name)
+	}
+
+	var component []string
+	for _, c := range issue.Fields.Components {
+		component = append(component, c.Name)
+	}
+
+	assignee := ""
+	if issue.Fields.Assignee != nil {
+		assignee = issue.Fields.Assignee.EmailAddress
+	}
+
+	var id int
+	fmt.Sscanf(issue.Key, issue.Fields.Project.Key+"-%d", &id)
+
+	return tracker.Issue{
+		ID:            id,
+		URL:           fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(baseURL, "/"), issue.Key),
+		Summary:       issue.Fields.Summary,
+		Status:        issue.Fields.Status.Name,
+		AssignedTo:    assignee,
+		Component:     component,
+		TargetRelease: targetRelease,
+		Flags:         flags,
+		Escalated:     hasLabel(issue.Fields.Labels, "escalated"),
+	}
+}
+
+func hasLabel(labels []string, name string) bool {
+	for _, l := range labels {
+		if l == name {
+			return true
+		}
+	}
+	return false
+}
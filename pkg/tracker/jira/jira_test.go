@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+
+	jiralib "github.com/andygrunwald/go-jira"
+
+	"github.com/mfojtik/bugzilla-operator/pkg/tracker"
+)
+
+func TestBuildJQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query tracker.Query
+		want  []string
+	}{
+		{
+			name:  "statuses",
+			query: tracker.Query{Statuses: []string{"New", "Assigned"}},
+			want:  []string{`AND status in ("New", "Assigned")`},
+		},
+		{
+			name:  "components",
+			query: tracker.Query{Components: []string{"etcd"}},
+			want:  []string{`AND component in ("etcd")`},
+		},
+		{
+			name:  "target release maps onto fixVersion",
+			query: tracker.Query{TargetRelease: []string{"4.12.0"}},
+			want:  []string{`AND fixVersion in ("4.12.0")`},
+		},
+		{
+			name:  "id cursor",
+			query: tracker.Query{IDGreaterThan: 100},
+			want:  []string{"AND key > OCPBUGS-100"},
+		},
+		{
+			name:  "excluding low priority/severity excludes low priority",
+			query: tracker.Query{ExcludeLowPrioritySeverity: true},
+			want:  []string{"AND priority != Low"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildJQL("OCPBUGS", tt.query)
+			if !strings.HasPrefix(got, "project = OCPBUGS") {
+				t.Errorf("jql %q missing expected project prefix", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("jql %q missing %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	issue := jiralib.Issue{
+		Key: "OCPBUGS-123",
+		Fields: &jiralib.IssueFields{
+			Summary: "etcd leader flaps",
+			Status:  &jiralib.Status{Name: "New"},
+			Project: jiralib.Project{Key: "OCPBUGS"},
+			Assignee: &jiralib.User{
+				EmailAddress: "developer@example.com",
+			},
+			Components: []*jiralib.Component{{Name: "etcd"}},
+			FixVersions: []*jiralib.FixVersion{
+				{Name: "4.12.0"},
+			},
+			Labels: []string{"blocker-plus", "escalated"},
+		},
+	}
+
+	got := convert(issue, "https://issues.example.com")
+
+	if got.ID != 123 {
+		t.Errorf("ID = %d, want 123", got.ID)
+	}
+	if got.URL != "https://issues.example.com/browse/OCPBUGS-123" {
+		t.Errorf("URL = %q, want the browse URL for OCPBUGS-123", got.URL)
+	}
+	if got.AssignedTo != "developer@example.com" {
+		t.Errorf("AssignedTo = %q, want developer@example.com", got.AssignedTo)
+	}
+	if len(got.Component) != 1 || got.Component[0] != "etcd" {
+		t.Errorf("Component = %v, want [etcd]", got.Component)
+	}
+	if len(got.TargetRelease) != 1 || got.TargetRelease[0] != "4.12.0" {
+		t.Errorf("TargetRelease = %v, want [4.12.0]", got.TargetRelease)
+	}
+	if !got.HasFlag("blocker", "+") {
+		t.Errorf("flags = %v, want a blocker:+ flag", got.Flags)
+	}
+	if !got.Escalated {
+		t.Error("expected issue carrying the escalated label to be Escalated")
+	}
+}
+
+func TestConvertFlagValues(t *testing.T) {
+	issue := jiralib.Issue{
+		Key: "OCPBUGS-124",
+		Fields: &jiralib.IssueFields{
+			Project: jiralib.Project{Key: "OCPBUGS"},
+			Labels:  []string{"blocker-questionmark", "triage-minus", "needinfo"},
+		},
+	}
+
+	got := convert(issue, "https://issues.example.com")
+
+	if !got.HasFlag("blocker", "?") {
+		t.Errorf("flags = %v, want a blocker:? flag", got.Flags)
+	}
+	if !got.HasFlag("triage", "-") {
+		t.Errorf("flags = %v, want a triage:- flag", got.Flags)
+	}
+	if !got.HasFlag("needinfo", "+") {
+		t.Errorf("flags = %v, want a bare needinfo label mapped to needinfo:+", got.Flags)
+	}
+}
@@ -0,0 +1,100 @@
+// Package tracker abstracts the subset of issue-tracker operations the
+// blockers, escalation and new-bug reporters need, so that reporting logic
+// does not have to hard-code github.com/eparis/bugzilla types and Red
+// Hat/OCP-specific fields. pkg/tracker/bugzilla adapts the existing Bugzilla
+// client; pkg/tracker/github and pkg/tracker/jira let components that moved
+// off Bugzilla keep using the same reporters.
+package tracker
+
+// Flag is a generic representation of a Bugzilla-style tri-state flag
+// (e.g. "blocker"/"+"). GitHub labels and Jira labels are mapped onto the
+// same shape by their respective adapters.
+type Flag struct {
+	Name  string
+	Value string
+}
+
+// ExternalLink records an issue linked in another system, e.g. a Salesforce
+// case number, used by the escalation reporter to detect customer-reported
+// bugs regardless of backend.
+type ExternalLink struct {
+	System string
+	ID     string
+}
+
+// Issue is the tracker-agnostic view of a single bug/issue that reporting
+// logic operates on.
+type Issue struct {
+	ID      int
+	URL     string
+	Summary string
+	Status  string
+
+	AssignedTo string
+	Component  []string
+
+	Keywords   []string
+	Whiteboard string
+
+	Severity string
+	Priority string
+
+	// TargetRelease is the Bugzilla target_release equivalent: a GitHub
+	// milestone name or a Jira fixVersion.
+	TargetRelease []string
+
+	Flags []Flag
+
+	// Escalated is true if the issue carries this backend's "escalated to
+	// support" marker (Bugzilla's cf_internal_whiteboard Escalation field;
+	// an "escalated" label on GitHub and on Jira).
+	Escalated     bool
+	ExternalLinks []ExternalLink
+}
+
+// HasFlag reports whether the issue carries flag name set to value.
+func (i Issue) HasFlag(name, value string) bool {
+	for _, f := range i.Flags {
+		if f.Name == name && f.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Query is the tracker-agnostic search request. Each adapter translates it
+// into its backend's native query shape (bugzilla.Query, a GitHub search
+// string, a JQL string, ...).
+type Query struct {
+	Components    []string
+	Statuses      []string
+	TargetRelease []string
+
+	// ExcludeLowPrioritySeverity drops issues whose priority or severity is
+	// the backend's lowest tier. The Jira adapter filters on priority only.
+	ExcludeLowPrioritySeverity bool
+
+	// CreatedSince, if set, restricts the search to issues created after
+	// this relative cutoff (e.g. "-24h").
+	CreatedSince string
+
+	// IDGreaterThan, if > 0, restricts the search to issues numbered after
+	// it, used by the new-bug reporter's incremental cursor.
+	IDGreaterThan int
+}
+
+// Client is the tracker-agnostic subset of operations reporters need from an
+// issue tracker backend.
+type Client interface {
+	Search(q Query) ([]Issue, error)
+
+	// SetPriority sets id's priority, e.g. in response to a "Set
+	// Priority=high" button click.
+	SetPriority(id int, priority string) error
+	// SetNeedinfo requests more information from the bug's requester.
+	SetNeedinfo(id int) error
+	// AddComment posts a comment to id.
+	AddComment(id int, body string) error
+	// Link returns a human-facing URL for id, for use in Slack messages.
+	Link(id int) string
+}